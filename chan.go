@@ -0,0 +1,232 @@
+package fun
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+// The channel stages below each start a goroutine that reads from the input
+// channel and writes to a new, unbuffered output channel. The output channel
+// is closed once the input channel is closed and drained, or once the context
+// is done, at which point the goroutine exits. Elements read from the input
+// but not yet delivered when the context is done are dropped.
+
+// ChanSeq returns a sequence over the elements received from the given
+// channel. The sequence ends when the channel is closed or the context is done.
+func ChanSeq[T any](ctx context.Context, in <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-in:
+				if !ok || !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqChan starts a goroutine that sends the elements of the given sequence to
+// the returned channel, closing it when the sequence ends or the context is
+// done
+func SeqChan[T any](ctx context.Context, seq iter.Seq[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for e := range seq {
+			if !send(ctx, out, e) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// MapChan returns a channel of the results of applying the given function to
+// every element received from the input channel
+func MapChan[T1, T2 any](ctx context.Context, in <-chan T1, fn func(T1) T2) <-chan T2 {
+	return SeqChan(ctx, MapSeq(ChanSeq(ctx, in), fn))
+}
+
+// FilterChan returns a channel of the elements received from the input channel
+// for which the given function returns true
+func FilterChan[T any](ctx context.Context, in <-chan T, fn func(T) bool) <-chan T {
+	return SeqChan(ctx, FilterSeq(ChanSeq(ctx, in), fn))
+}
+
+// FlatMapChan returns a channel of all the elements of the slices obtained by
+// applying the given function to every element received from the input channel
+func FlatMapChan[T1, T2 any](ctx context.Context, in <-chan T1, fn func(T1) []T2) <-chan T2 {
+	return SeqChan(ctx, FlatMapSeq(ChanSeq(ctx, in), fn))
+}
+
+// DistinctChan returns a channel of the elements received from the input
+// channel, skipping any element that was already seen
+func DistinctChan[T comparable](ctx context.Context, in <-chan T) <-chan T {
+	return SeqChan(ctx, DistinctSeq(ChanSeq(ctx, in)))
+}
+
+// DistinctByChan returns a channel of the elements received from the input
+// channel, skipping any element whose key, as returned by the given selector
+// function, was already seen
+func DistinctByChan[T any, K comparable](ctx context.Context, in <-chan T, fn func(T) K) <-chan T {
+	return SeqChan(ctx, DistinctBySeq(ChanSeq(ctx, in), fn))
+}
+
+// ChunkChan returns a channel of slices of the elements received from the input
+// channel, each of the given size. The last slice, sent when the input channel
+// is closed, might have fewer elements. Like Chunked, it panics if size is not
+// positive.
+func ChunkChan[T any](ctx context.Context, in <-chan T, size int) <-chan []T {
+	return SeqChan(ctx, ChunkedSeq(ChanSeq(ctx, in), size))
+}
+
+// ChunkChanLatency is like ChunkChan, but also sends a partial chunk once the
+// given latency has passed since its first element was received, so no element
+// waits longer than that to be sent downstream. It panics if size or
+// maxLatency is not positive.
+func ChunkChanLatency[T any](
+	ctx context.Context,
+	in <-chan T,
+	size int,
+	maxLatency time.Duration,
+) <-chan []T {
+	mustCheck(checkSize(size))
+	mustCheck(checkLatency(maxLatency))
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(maxLatency)
+		timer.Stop()
+		defer timer.Stop()
+		var chunk []T
+		flush := func() bool {
+			timer.Stop()
+			c := chunk
+			chunk = nil
+			return send(ctx, out, c)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				if len(chunk) > 0 && !flush() {
+					return
+				}
+			case e, ok := <-in:
+				if !ok {
+					if len(chunk) > 0 {
+						flush()
+					}
+					return
+				}
+				if len(chunk) == 0 {
+					timer.Reset(maxLatency)
+				}
+				chunk = append(chunk, e)
+				if len(chunk) == size && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// WindowChan returns a channel of sliding windows over the elements received
+// from the input channel, of the given size and with the given step. When the
+// input channel is closed the trailing partial windows are sent, as in
+// Windowed. Like Windowed, it panics if size or step is not positive.
+func WindowChan[T any](ctx context.Context, in <-chan T, size, step int) <-chan []T {
+	return SeqChan(ctx, WindowedSeq(ChanSeq(ctx, in), size, step))
+}
+
+// MergeChan returns a channel of the elements received from all the given
+// channels, in the order they arrive. The returned channel is closed once all
+// the input channels are closed.
+func MergeChan[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func(in <-chan T) {
+			defer wg.Done()
+			for e := range ChanSeq(ctx, in) {
+				if !send(ctx, out, e) {
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut returns n channels that share the elements received from the input
+// channel between them, each element going to exactly one of the channels
+// whose reader is ready. All the channels are closed when the input is. It
+// panics if n is not positive.
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	mustCheck(checkChanCount(n))
+	ret := make([]<-chan T, n)
+	for i := range ret {
+		out := make(chan T)
+		ret[i] = out
+		go func() {
+			defer close(out)
+			for e := range ChanSeq(ctx, in) {
+				if !send(ctx, out, e) {
+					return
+				}
+			}
+		}()
+	}
+	return ret
+}
+
+// Tee returns n channels that each receive every element received from the
+// input channel. An element is sent to all the channels before the next one is
+// read, so the slowest reader sets the pace. It panics if n is not positive.
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	mustCheck(checkChanCount(n))
+	outs := make([]chan T, n)
+	ret := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		ret[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for e := range ChanSeq(ctx, in) {
+			for _, out := range outs {
+				if !send(ctx, out, e) {
+					return
+				}
+			}
+		}
+	}()
+	return ret
+}
+
+// send sends the given value on the channel, returning false if the context
+// was done first
+func send[T any](ctx context.Context, out chan<- T, e T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- e:
+		return true
+	}
+}
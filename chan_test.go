@@ -0,0 +1,214 @@
+package fun
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// checkGoroutines fails the test if, shortly after it ends, more goroutines
+// are running than when it started
+func checkGoroutines(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(2 * time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("leaked goroutines: %d before, %d after", before, after)
+		}
+	})
+}
+
+// source returns a channel that receives the given elements and is then closed
+func source[T any](ctx context.Context, s ...T) <-chan T {
+	return SeqChan(ctx, AsSeq(s))
+}
+
+func drain[T any](c <-chan T) []T {
+	ret := make([]T, 0)
+	for e := range c {
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+func TestMapFilterFlatMapChan(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	got := drain(MapChan(ctx, source(ctx, 1, 2, 3), func(i int) int { return i * 10 }))
+	if want := []int{10, 20, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapChan() = %v, want %v", got, want)
+	}
+	got = drain(FilterChan(ctx, source(ctx, 1, 2, 3, 4), func(i int) bool { return i%2 == 0 }))
+	if want := []int{2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterChan() = %v, want %v", got, want)
+	}
+	got = drain(FlatMapChan(ctx, source(ctx, 1, 2, 3), func(i int) []int { return slices.Repeat([]int{i}, i) }))
+	if want := []int{1, 2, 2, 3, 3, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlatMapChan() = %v, want %v", got, want)
+	}
+}
+
+func TestDistinctChan(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	got := drain(DistinctChan(ctx, source(ctx, 1, 2, 1, 3, 2)))
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctChan() = %v, want %v", got, want)
+	}
+	words := drain(DistinctByChan(ctx, source(ctx, "a", "bb", "c", "dd", "eee"), func(s string) int { return len(s) }))
+	if want := []string{"a", "bb", "eee"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("DistinctByChan() = %v, want %v", words, want)
+	}
+}
+
+func TestChunkChan(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	got := drain(ChunkChan(ctx, source(ctx, 1, 2, 3, 4, 5), 2))
+	if want := [][]int{{1, 2}, {3, 4}, {5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkChan() = %v, want %v", got, want)
+	}
+	got = drain(ChunkChanLatency(ctx, source(ctx, 1, 2, 3, 4, 5), 2, time.Hour))
+	if want := [][]int{{1, 2}, {3, 4}, {5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkChanLatency() = %v, want %v", got, want)
+	}
+	// a large size must not be allocated up front inside the goroutine
+	got = drain(ChunkChan(ctx, source(ctx, 1, 2, 3), math.MaxInt))
+	if want := [][]int{{1, 2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkChan(MaxInt) = %v, want %v", got, want)
+	}
+	got = drain(ChunkChanLatency(ctx, source(ctx, 1, 2, 3), math.MaxInt, time.Hour))
+	if want := [][]int{{1, 2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkChanLatency(MaxInt) = %v, want %v", got, want)
+	}
+}
+
+func TestChanBadArgs(t *testing.T) {
+	ctx := context.Background()
+	for _, n := range []int{0, -1} {
+		for name, fn := range map[string]func(){
+			"FanOut": func() { FanOut(ctx, make(chan int), n) },
+			"Tee":    func() { Tee(ctx, make(chan int), n) },
+		} {
+			if err := panicErr(fn); !errors.Is(err, ErrInvalidChanCount) {
+				t.Errorf("%s(%d) panic = %v, want ErrInvalidChanCount", name, n, err)
+			}
+		}
+	}
+	for _, d := range []time.Duration{0, -time.Second} {
+		err := panicErr(func() { ChunkChanLatency(ctx, make(chan int), 2, d) })
+		if !errors.Is(err, ErrInvalidLatency) {
+			t.Errorf("ChunkChanLatency(%v) panic = %v, want ErrInvalidLatency", d, err)
+		}
+	}
+}
+
+func TestChunkChanLatencyFlushesPartialChunk(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	in := make(chan int)
+	out := ChunkChanLatency(ctx, in, 10, 20*time.Millisecond)
+	in <- 1
+	in <- 2
+	select {
+	case got := <-out:
+		if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+			t.Errorf("ChunkChanLatency() = %v, want %v", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ChunkChanLatency() did not flush a partial chunk")
+	}
+	in <- 3
+	close(in)
+	if got, want := drain(out), [][]int{{3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkChanLatency() after close = %v, want %v", got, want)
+	}
+}
+
+func TestWindowChan(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	got := drain(WindowChan(ctx, source(ctx, 1, 2, 3, 4, 5), 3, 2))
+	if want := Windowed([]int{1, 2, 3, 4, 5}, 3, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowChan() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeChan(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	got := drain(MergeChan(ctx, source(ctx, 1, 2, 3), source(ctx, 4, 5), source[int](ctx)))
+	slices.Sort(got)
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeChan() = %v, want %v", got, want)
+	}
+	if got := drain(MergeChan[int](ctx)); len(got) != 0 {
+		t.Errorf("MergeChan() of no channels = %v, want []", got)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = i
+	}
+	outs := FanOut(ctx, source(ctx, want...), 3)
+	got := drain(MergeChan(ctx, outs...))
+	slices.Sort(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FanOut() = %v, want %v", got, want)
+	}
+}
+
+func TestTee(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	outs := Tee(ctx, source(ctx, 1, 2, 3), 2)
+	results := make(chan []int, len(outs))
+	for _, out := range outs {
+		go func() { results <- drain(out) }()
+	}
+	for range outs {
+		if got, want := <-results, []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Tee() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestChanCancel(t *testing.T) {
+	checkGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	// an endless source whose downstream reader stops after a few elements
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; ; i++ {
+			if !send(ctx, in, i) {
+				return
+			}
+		}
+	}()
+	outs := Tee(ctx, FanOut(ctx, in, 2)[0], 2)
+	pipeline := MergeChan(ctx,
+		MapChan(ctx, outs[0], func(i int) int { return i }),
+		FilterChan(ctx, DistinctChan(ctx, outs[1]), func(int) bool { return true }),
+	)
+	chunks := ChunkChanLatency(ctx, WindowChan(ctx, pipeline, 2, 1), 3, time.Millisecond)
+	<-chunks
+	<-chunks
+	cancel()
+	// every stage closes its output once the context is done
+	for range chunks {
+	}
+}
@@ -0,0 +1,129 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidChunkSize is returned by ChunkedChecked and WindowedChecked
+	// for a chunk or window size that is not positive. Chunked, Windowed and
+	// their variants panic with an error wrapping it.
+	ErrInvalidChunkSize = errors.New("fun: chunk or window size must be positive")
+	// ErrInvalidStep is returned by WindowedChecked for a step that is not
+	// positive. Windowed and its variants panic with an error wrapping it.
+	ErrInvalidStep = errors.New("fun: window step must be positive")
+	// ErrNegativeCount is returned by TakeChecked, TakeLastChecked,
+	// DropChecked and DropLastChecked for a negative count. Take, TakeLast,
+	// Drop and DropLast treat a negative count as zero instead.
+	ErrNegativeCount = errors.New("fun: count must not be negative")
+	// ErrInvalidChanCount is the error wrapped by the panic of FanOut and Tee
+	// for a number of output channels that is not positive
+	ErrInvalidChanCount = errors.New("fun: number of channels must be positive")
+	// ErrInvalidLatency is the error wrapped by the panic of ChunkChanLatency
+	// for a latency that is not positive
+	ErrInvalidLatency = errors.New("fun: latency must be positive")
+)
+
+// ChunkedChecked is like Chunked, but returns an error wrapping
+// ErrInvalidChunkSize instead of panicking if chunkSize is not positive
+func ChunkedChecked[T any](s []T, chunkSize int) ([][]T, error) {
+	if err := checkSize(chunkSize); err != nil {
+		return nil, err
+	}
+	return Chunked(s, chunkSize), nil
+}
+
+// WindowedChecked is like Windowed, but returns an error wrapping
+// ErrInvalidChunkSize or ErrInvalidStep instead of panicking if size or step
+// is not positive
+func WindowedChecked[T any](s []T, size, step int) ([][]T, error) {
+	if err := checkWindow(size, step); err != nil {
+		return nil, err
+	}
+	return Windowed(s, size, step), nil
+}
+
+// TakeChecked is like Take, but returns an error wrapping ErrNegativeCount for
+// a negative n
+func TakeChecked[T any](s []T, n int) ([]T, error) {
+	if err := checkCount(n); err != nil {
+		return nil, err
+	}
+	return Take(s, n), nil
+}
+
+// TakeLastChecked is like TakeLast, but returns an error wrapping
+// ErrNegativeCount for a negative n
+func TakeLastChecked[T any](s []T, n int) ([]T, error) {
+	if err := checkCount(n); err != nil {
+		return nil, err
+	}
+	return TakeLast(s, n), nil
+}
+
+// DropChecked is like Drop, but returns an error wrapping ErrNegativeCount for
+// a negative n
+func DropChecked[T any](s []T, n int) ([]T, error) {
+	if err := checkCount(n); err != nil {
+		return nil, err
+	}
+	return Drop(s, n), nil
+}
+
+// DropLastChecked is like DropLast, but returns an error wrapping
+// ErrNegativeCount for a negative n
+func DropLastChecked[T any](s []T, n int) ([]T, error) {
+	if err := checkCount(n); err != nil {
+		return nil, err
+	}
+	return DropLast(s, n), nil
+}
+
+func checkSize(size int) error {
+	if size <= 0 {
+		return fmt.Errorf("%w, got %d", ErrInvalidChunkSize, size)
+	}
+	return nil
+}
+
+func checkWindow(size, step int) error {
+	if err := checkSize(size); err != nil {
+		return err
+	}
+	if step <= 0 {
+		return fmt.Errorf("%w, got %d", ErrInvalidStep, step)
+	}
+	return nil
+}
+
+func checkCount(n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w, got %d", ErrNegativeCount, n)
+	}
+	return nil
+}
+
+func checkChanCount(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("%w, got %d", ErrInvalidChanCount, n)
+	}
+	return nil
+}
+
+func checkLatency(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%w, got %v", ErrInvalidLatency, d)
+	}
+	return nil
+}
+
+// mustCheck panics with the given error, if any. The unchecked functions use it
+// so that bad arguments fail at the call, with an error naming the problem,
+// rather than with a division by zero or a slice bounds error later on.
+func mustCheck(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
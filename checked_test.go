@@ -0,0 +1,138 @@
+package fun
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// panicErr returns the error fn panics with, or nil if it does not panic
+func panicErr(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+			if err == nil {
+				err = errors.New("panic with a non-error value")
+			}
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestChunkedBadSize(t *testing.T) {
+	s := []int{1, 2, 3}
+	for _, size := range []int{0, -1} {
+		if _, err := ChunkedChecked(s, size); !errors.Is(err, ErrInvalidChunkSize) {
+			t.Errorf("ChunkedChecked(%d) error = %v, want ErrInvalidChunkSize", size, err)
+		}
+		unchecked := map[string]func(){
+			"Chunked":          func() { Chunked(s, size) },
+			"ChunkedView":      func() { ChunkedView(s, size) },
+			"ChunkedSeq":       func() { ChunkedSeq(AsSeq(s), size) },
+			"ChunkChanLatency": func() { ChunkChanLatency(context.Background(), make(chan int), size, 0) },
+		}
+		for name, fn := range unchecked {
+			if err := panicErr(fn); !errors.Is(err, ErrInvalidChunkSize) {
+				t.Errorf("%s(%d) panic = %v, want ErrInvalidChunkSize", name, size, err)
+			}
+		}
+	}
+	got, err := ChunkedChecked(s, 2)
+	if want := [][]int{{1, 2}, {3}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkedChecked(2) = %v, %v, want %v", got, err, want)
+	}
+	// any positive size is valid, however large
+	got, err = ChunkedChecked(s, math.MaxInt)
+	if want := [][]int{{1, 2, 3}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkedChecked(MaxInt) = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestWindowedBadArgs(t *testing.T) {
+	s := []int{1, 2, 3}
+	tests := []struct {
+		name       string
+		size, step int
+		want       error
+	}{
+		{"zero size", 0, 1, ErrInvalidChunkSize},
+		{"negative size", -2, 1, ErrInvalidChunkSize},
+		{"zero step", 2, 0, ErrInvalidStep},
+		{"negative step", 2, -1, ErrInvalidStep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := WindowedChecked(s, tt.size, tt.step); !errors.Is(err, tt.want) {
+				t.Errorf("WindowedChecked() error = %v, want %v", err, tt.want)
+			}
+			unchecked := map[string]func(){
+				"Windowed":     func() { Windowed(s, tt.size, tt.step) },
+				"WindowedView": func() { WindowedView(s, tt.size, tt.step) },
+				"WindowedSeq":  func() { WindowedSeq(AsSeq(s), tt.size, tt.step) },
+			}
+			for name, fn := range unchecked {
+				if err := panicErr(fn); !errors.Is(err, tt.want) {
+					t.Errorf("%s() panic = %v, want %v", name, err, tt.want)
+				}
+			}
+		})
+	}
+	got, err := WindowedChecked(s, 2, 1)
+	if want := [][]int{{1, 2}, {2, 3}, {3}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowedChecked(2, 1) = %v, %v, want %v", got, err, want)
+	}
+	got, err = WindowedChecked(s, math.MaxInt, math.MaxInt)
+	if want := [][]int{{1, 2, 3}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowedChecked(MaxInt, MaxInt) = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestTakeDropCounts(t *testing.T) {
+	s := []int{1, 2, 3}
+	tests := []struct {
+		name    string
+		fn      func([]int, int) []int
+		checked func([]int, int) ([]int, error)
+		seq     func(iter.Seq[int], int) iter.Seq[int]
+		// want maps a count to the expected result
+		want map[int][]int
+	}{
+		{"Take", Take[int], TakeChecked[int], TakeSeq[int], map[int][]int{
+			-1: {}, 0: {}, 2: {1, 2}, 3: {1, 2, 3}, 4: {1, 2, 3},
+		}},
+		{"TakeLast", TakeLast[int], TakeLastChecked[int], TakeLastSeq[int], map[int][]int{
+			-1: {}, 0: {}, 2: {2, 3}, 3: {1, 2, 3}, 4: {1, 2, 3},
+		}},
+		{"Drop", Drop[int], DropChecked[int], DropSeq[int], map[int][]int{
+			-1: {1, 2, 3}, 0: {1, 2, 3}, 2: {3}, 3: {}, 4: {},
+		}},
+		{"DropLast", DropLast[int], DropLastChecked[int], DropLastSeq[int], map[int][]int{
+			-1: {1, 2, 3}, 0: {1, 2, 3}, 2: {1}, 3: {}, 4: {},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for n, want := range tt.want {
+				if got := tt.fn(s, n); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s(%d) = %v, want %v", tt.name, n, got, want)
+				}
+				// the slice and sequence versions agree on every count
+				if got := Collect(tt.seq(AsSeq(s), n)); !reflect.DeepEqual(got, want) {
+					t.Errorf("%sSeq(%d) = %v, want %v", tt.name, n, got, want)
+				}
+				got, err := tt.checked(s, n)
+				if n < 0 {
+					if !errors.Is(err, ErrNegativeCount) || got != nil {
+						t.Errorf("%sChecked(%d) = %v, %v, want ErrNegativeCount", tt.name, n, got, err)
+					}
+				} else if err != nil || !reflect.DeepEqual(got, want) {
+					t.Errorf("%sChecked(%d) = %v, %v, want %v", tt.name, n, got, err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package fun
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// CollisionError records that several sources produced the same Key: the
+// source keys of a map for TransformMapUnique, or the indices of the elements
+// of a slice for AssociateUnique. Sources are in ascending order. Functions
+// that find more than one collision join one CollisionError for each colliding
+// key.
+type CollisionError[K comparable, S any] struct {
+	Key     K
+	Sources []S
+}
+
+func (e *CollisionError[K, S]) Error() string {
+	return fmt.Sprintf("key %v: produced by %v", e.Key, e.Sources)
+}
+
+// KeepFirst resolves a collision by keeping the value seen first. It can be
+// passed to Merge, TransformMapWith, AssociateResolve and the other functions
+// that take a resolver.
+func KeepFirst[K comparable, V any](_ K, a, _ V) V {
+	return a
+}
+
+// KeepLast resolves a collision by keeping the value seen last. It can be
+// passed to Merge, TransformMapWith, AssociateResolve and the other functions
+// that take a resolver.
+func KeepLast[K comparable, V any](_ K, _, b V) V {
+	return b
+}
+
+// TransformMapWith is like TransformMap, but deterministic when the given
+// function returns the same key for several entries. Entries are visited in
+// ascending order of their original keys, and when a new key is produced more
+// than once, the resolve function is called with the new key, the value kept
+// so far and the next value, and returns the value to keep. KeepFirst and
+// KeepLast keep the value of the smallest or largest original key.
+func TransformMapWith[M ~map[K]V, K cmp.Ordered, V any](
+	m M,
+	fn func(k K, v V) (K, V, bool),
+	resolve func(k K, a, b V) V,
+) M {
+	ret := make(M, len(m))
+	for _, k := range SortedKeys(m) {
+		newK, newV, include := fn(k, m[k])
+		if !include {
+			continue
+		}
+		if prev, ok := ret[newK]; ok {
+			newV = resolve(newK, prev, newV)
+		}
+		ret[newK] = newV
+	}
+	return ret
+}
+
+// TransformMapToGroup is like TransformMap, but collects the values for each
+// new key into a slice, in ascending order of their original keys, so that no
+// value is lost when the given function returns the same key for several
+// entries
+func TransformMapToGroup[M ~map[K]V, K cmp.Ordered, V any](
+	m M,
+	fn func(k K, v V) (K, V, bool),
+) map[K][]V {
+	ret := make(map[K][]V, len(m))
+	for _, k := range SortedKeys(m) {
+		if newK, newV, include := fn(k, m[k]); include {
+			AppendToGroup(ret, newK, newV)
+		}
+	}
+	return ret
+}
+
+// TransformMapUnique is like TransformMap, but returns an error instead of a
+// map if the given function returns the same key for several entries. The
+// error joins a CollisionError for each such key, listing the original keys
+// that produced it.
+func TransformMapUnique[M ~map[K]V, K cmp.Ordered, V any](
+	m M,
+	fn func(k K, v V) (K, V, bool),
+) (M, error) {
+	ret := make(M, len(m))
+	sources := make(map[K][]K, len(m))
+	for _, k := range SortedKeys(m) {
+		if newK, newV, include := fn(k, m[k]); include {
+			ret[newK] = newV
+			AppendToGroup(sources, newK, k)
+		}
+	}
+	if err := collisions(SortedKeys(sources), sources); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// collisions returns the join of a CollisionError for every key with more than
+// one source, taking the keys in the given order, or nil if there are none.
+// The sources of each key must already be in ascending order.
+func collisions[K comparable, S any](keys []K, sources map[K][]S) error {
+	var errs []error
+	for _, k := range keys {
+		if ss := sources[k]; len(ss) > 1 {
+			errs = append(errs, &CollisionError[K, S]{Key: k, Sources: ss})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AssociateResolve is like Associate, but when several elements produce the
+// same key, the resolve function is called with the key, the value kept so far
+// and the next value, in the order of the elements, and returns the value to
+// keep. Pass KeepFirst or KeepLast to keep the first or last occurrence.
+func AssociateResolve[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V),
+	resolve func(k K, a, b V) V,
+) map[K]V {
+	ret := make(map[K]V)
+	for _, e := range s {
+		k, v := fn(e)
+		if prev, ok := ret[k]; ok {
+			v = resolve(k, prev, v)
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+// AssociateUnique is like Associate, but returns an error instead of a map if
+// several elements produce the same key. The error joins a CollisionError for
+// each such key, in order of first occurrence, listing the indices of the
+// elements that produced it.
+func AssociateUnique[T, V any, K comparable](s []T, fn func(T) (K, V)) (map[K]V, error) {
+	ret := make(map[K]V)
+	sources := make(map[K][]int)
+	var keys []K
+	for i, e := range s {
+		k, v := fn(e)
+		if _, ok := sources[k]; !ok {
+			keys = append(keys, k)
+		}
+		ret[k] = v
+		AppendToGroup(sources, k, i)
+	}
+	if err := collisions(keys, sources); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,108 @@
+package fun
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lowerKeys maps keys to lower case, so that "B" and "b" collide
+func lowerKeys(k string, v int) (string, int, bool) {
+	return strings.ToLower(k), v, true
+}
+
+var mixedCase = map[string]int{"A": 1, "B": 2, "b": 3, "C": 4, "c": 5, "cC": 6}
+
+func TestTransformMapWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		resolve func(string, int, int) int
+		want    map[string]int
+	}{
+		{"keep first", KeepFirst, map[string]int{"a": 1, "b": 2, "c": 4, "cc": 6}},
+		{"keep last", KeepLast, map[string]int{"a": 1, "b": 3, "c": 5, "cc": 6}},
+		{"merge", func(_ string, a, b int) int { return a*10 + b }, map[string]int{"a": 1, "b": 23, "c": 45, "cc": 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// repeat to catch any dependence on map iteration order
+			for range 20 {
+				if got := TransformMapWith(mixedCase, lowerKeys, tt.resolve); !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("TransformMapWith() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+	got := TransformMapWith(mixedCase, func(k string, v int) (string, int, bool) {
+		return k, v, v%2 == 0
+	}, KeepFirst)
+	if want := map[string]int{"B": 2, "C": 4, "cC": 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TransformMapWith(filtered) = %v, want %v", got, want)
+	}
+}
+
+func TestTransformMapToGroup(t *testing.T) {
+	got := TransformMapToGroup(mixedCase, lowerKeys)
+	want := map[string][]int{"a": {1}, "b": {2, 3}, "c": {4, 5}, "cc": {6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TransformMapToGroup() = %v, want %v", got, want)
+	}
+}
+
+func TestTransformMapUnique(t *testing.T) {
+	_, err := TransformMapUnique(mixedCase, lowerKeys)
+	want := "key b: produced by [B b]\nkey c: produced by [C c]"
+	if err == nil || err.Error() != want {
+		t.Fatalf("TransformMapUnique() error = %v, want %q", err, want)
+	}
+	var ce *CollisionError[string, string]
+	if !errors.As(err, &ce) || ce.Key != "b" || !reflect.DeepEqual(ce.Sources, []string{"B", "b"}) {
+		t.Errorf("TransformMapUnique() error does not hold a CollisionError for b: %#v", ce)
+	}
+	got, err := TransformMapUnique(mixedCase, func(k string, v int) (string, int, bool) {
+		return k + "!", v, true
+	})
+	if err != nil || len(got) != len(mixedCase) || got["cC!"] != 6 {
+		t.Errorf("TransformMapUnique(no collisions) = %v, %v", got, err)
+	}
+}
+
+func TestAssociateResolve(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	byInitial := func(w string) (byte, string) { return w[0], w }
+	tests := []struct {
+		name    string
+		resolve func(byte, string, string) string
+		want    map[byte]string
+	}{
+		{"KeepFirst", KeepFirst, map[byte]string{'a': "apple", 'b': "banana", 'c': "cherry"}},
+		{"KeepLast", KeepLast, map[byte]string{'a': "avocado", 'b': "blueberry", 'c': "cherry"}},
+		{"concat", func(_ byte, a, b string) string { return a + "," + b },
+			map[byte]string{'a': "apple,avocado", 'b': "banana,blueberry", 'c': "cherry"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AssociateResolve(words, byInitial, tt.resolve); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssociateResolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssociateUnique(t *testing.T) {
+	words := []string{"cherry", "banana", "cranberry", "apple", "blueberry", "coconut"}
+	_, err := AssociateUnique(words, func(w string) (byte, string) { return w[0], w })
+	want := "key 99: produced by [0 2 5]\nkey 98: produced by [1 4]"
+	if err == nil || err.Error() != want {
+		t.Fatalf("AssociateUnique() error = %v, want %q", err, want)
+	}
+	var ce *CollisionError[byte, int]
+	if !errors.As(err, &ce) || ce.Key != 'c' || !reflect.DeepEqual(ce.Sources, []int{0, 2, 5}) {
+		t.Errorf("AssociateUnique() error does not hold a CollisionError for c: %#v", ce)
+	}
+	got, err := AssociateUnique(words, func(w string) (string, int) { return w, len(w) })
+	if err != nil || len(got) != len(words) || got["apple"] != 5 {
+		t.Errorf("AssociateUnique(no collisions) = %v, %v", got, err)
+	}
+}
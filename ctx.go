@@ -0,0 +1,112 @@
+package fun
+
+import "context"
+
+// FoldCtx accumulates values starting with given initial value and applying
+// given function to current accumulator and each element. The context is
+// checked before each element; once it is done, FoldCtx returns the
+// accumulator so far along with ctx.Err().
+func FoldCtx[T, R any](
+	ctx context.Context,
+	s []T,
+	initial R,
+	fn func(R, T) R,
+) (R, error) {
+	acc := initial
+	for _, e := range s {
+		if err := ctx.Err(); err != nil {
+			return acc, err
+		}
+		acc = fn(acc, e)
+	}
+	return acc, nil
+}
+
+// FoldIndexedCtx accumulates values starting with given initial value and
+// applying given function to current accumulator and each element. Function
+// also receives index of current element. The context is checked before each
+// element; once it is done, FoldIndexedCtx returns the accumulator so far
+// along with ctx.Err().
+func FoldIndexedCtx[T, R any](
+	ctx context.Context,
+	s []T,
+	initial R,
+	fn func(int, R, T) R,
+) (R, error) {
+	acc := initial
+	for i, e := range s {
+		if err := ctx.Err(); err != nil {
+			return acc, err
+		}
+		acc = fn(i, acc, e)
+	}
+	return acc, nil
+}
+
+// FoldItemsCtx accumulates values starting with given initial value and
+// applying given function to current accumulator and each key, value. The
+// context is checked before each entry; once it is done, FoldItemsCtx returns
+// the accumulator so far along with ctx.Err().
+func FoldItemsCtx[M ~map[K]V, K comparable, V, R any](
+	ctx context.Context,
+	m M,
+	initial R,
+	fn func(R, K, V) R,
+) (R, error) {
+	acc := initial
+	for k, v := range m {
+		if err := ctx.Err(); err != nil {
+			return acc, err
+		}
+		acc = fn(acc, k, v)
+	}
+	return acc, nil
+}
+
+// MapCtx returns the slice obtained after applying the given function over
+// every element in the given slice. The context is checked before each
+// element; once it is done, MapCtx returns the results for the elements
+// processed so far along with ctx.Err().
+func MapCtx[T1, T2 any](
+	ctx context.Context,
+	s []T1,
+	fn func(T1) T2,
+) ([]T2, error) {
+	ret := make([]T2, 0, len(s))
+	for _, e := range s {
+		if err := ctx.Err(); err != nil {
+			return ret, err
+		}
+		ret = append(ret, fn(e))
+	}
+	return ret, nil
+}
+
+// ParallelMapCtx is like ParallelMap, but no new elements are started once the
+// context is done. Callbacks already running are allowed to finish, after
+// which ParallelMapCtx returns, as MapCtx does, the results for the longest
+// prefix of the elements that were all processed, along with ctx.Err().
+func ParallelMapCtx[T1, T2 any](
+	ctx context.Context,
+	s []T1,
+	limit int,
+	fn func(T1) T2,
+) ([]T2, error) {
+	if len(s) == 0 {
+		return make([]T2, 0), nil
+	}
+	ret := make([]T2, len(s))
+	done := make([]bool, len(s))
+	err := parallelFor(ctx, len(s), limit, func(i int) {
+		ret[i] = fn(s[i])
+		done[i] = true
+	})
+	if err != nil {
+		n := 0
+		for n < len(done) && done[n] {
+			n++
+		}
+		return ret[:n:n], err
+	}
+	return ret, nil
+}
@@ -0,0 +1,146 @@
+package fun
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFoldCtx(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	sum := func(acc, v int) int { return acc + v }
+
+	got, err := FoldCtx(context.Background(), s, 0, sum)
+	if err != nil || got != 15 {
+		t.Errorf("FoldCtx() = %v, %v, want 15, nil", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = FoldCtx(ctx, s, 100, sum)
+	if !errors.Is(err, context.Canceled) || got != 100 {
+		t.Errorf("FoldCtx() = %v, %v, want 100, %v", got, err, context.Canceled)
+	}
+
+	// cancel after the third element; the fourth is never folded
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	got, err = FoldCtx(ctx, s, 0, func(acc, v int) int {
+		if v == 3 {
+			cancel()
+		}
+		return acc + v
+	})
+	if !errors.Is(err, context.Canceled) || got != 6 {
+		t.Errorf("FoldCtx() = %v, %v, want 6, %v", got, err, context.Canceled)
+	}
+}
+
+func TestFoldIndexedCtx(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	fn := func(i, acc, v int) int { return acc + i*v }
+	got, err := FoldIndexedCtx(context.Background(), s, 0, fn)
+	if want := FoldIndexed(s, 0, fn); err != nil || got != want {
+		t.Errorf("FoldIndexedCtx() = %v, %v, want %v, nil", got, err, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, err = FoldIndexedCtx(ctx, s, 0, func(i, acc, v int) int {
+		if i == 1 {
+			cancel()
+		}
+		return acc + i*v
+	})
+	if !errors.Is(err, context.Canceled) || got != 2 {
+		t.Errorf("FoldIndexedCtx() = %v, %v, want 2, %v", got, err, context.Canceled)
+	}
+}
+
+func TestFoldItemsCtx(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	sum := func(acc int, _ string, v int) int { return acc + v }
+	got, err := FoldItemsCtx(context.Background(), m, 0, sum)
+	if err != nil || got != 6 {
+		t.Errorf("FoldItemsCtx() = %v, %v, want 6, nil", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = FoldItemsCtx(ctx, m, 0, sum)
+	if !errors.Is(err, context.Canceled) || got != 0 {
+		t.Errorf("FoldItemsCtx() = %v, %v, want 0, %v", got, err, context.Canceled)
+	}
+}
+
+func TestMapCtx(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	square := func(i int) int { return i * i }
+	got, err := MapCtx(context.Background(), s, square)
+	if err != nil || !reflect.DeepEqual(got, Map(s, square)) {
+		t.Errorf("MapCtx() = %v, %v, want %v, nil", got, err, Map(s, square))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got, err = MapCtx(ctx, s, func(i int) int {
+		if i == 2 {
+			cancel()
+		}
+		return i * i
+	})
+	if !errors.Is(err, context.Canceled) || !reflect.DeepEqual(got, []int{1, 4}) {
+		t.Errorf("MapCtx() = %v, %v, want [1 4], %v", got, err, context.Canceled)
+	}
+}
+
+func TestParallelMapCtx(t *testing.T) {
+	s := make([]int, 200)
+	for i := range s {
+		s[i] = i
+	}
+	square := func(i int) int { return i * i }
+	got, err := ParallelMapCtx(context.Background(), s, 4, square)
+	if err != nil || !reflect.DeepEqual(got, Map(s, square)) {
+		t.Errorf("ParallelMapCtx() = %v, %v, want %v, nil", got, err, Map(s, square))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	got, err = ParallelMapCtx(ctx, s, 4, func(i int) int {
+		calls.Add(1)
+		return i
+	})
+	if !errors.Is(err, context.Canceled) || got == nil || len(got) != 0 || calls.Load() != 0 {
+		t.Errorf("ParallelMapCtx() = %v, %v after %d calls, want [], %v after 0 calls",
+			got, err, calls.Load(), context.Canceled)
+	}
+
+	// an empty slice is not an error, as in MapCtx
+	got, err = ParallelMapCtx(ctx, []int{}, 4, square)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("ParallelMapCtx(empty) = %v, %v, want [], nil", got, err)
+	}
+
+	// cancelling part way through stops new elements from starting, and the
+	// elements mapped so far are returned
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	calls.Store(0)
+	got, err = ParallelMapCtx(ctx, s, 1, func(i int) int {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+		return i * i
+	})
+	if !errors.Is(err, context.Canceled) || calls.Load() >= int32(len(s)) {
+		t.Errorf("ParallelMapCtx() = %v after %d calls, want %v before %d calls",
+			err, calls.Load(), context.Canceled, len(s))
+	}
+	if want := Map(s[:len(got)], square); len(got) < 10 || !reflect.DeepEqual(got, want) {
+		t.Errorf("ParallelMapCtx() partial result = %v, want the first %d or more squares", got, 10)
+	}
+}
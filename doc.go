@@ -0,0 +1,32 @@
+// Package fun provides simple generic utility functions to reduce Go
+// boilerplate, inspired by Kotlin and Rust collection functions.
+//
+// # Empty results
+//
+// Functions that return a slice or a map never return nil, even for nil or
+// empty input, so that results encode to JSON as [] or {} rather than null.
+// The exceptions are:
+//   - functions that return an error, whose other results are nil when the
+//     error is not
+//   - the InPlace and Into functions, which return the slice they were given,
+//     like the built-in append, and so return nil if it was nil
+//
+// # Aliasing
+//
+// Functions return new slices and maps, which do not share storage with their
+// input, except for the following, which return views into their input or share its
+// storage:
+//   - Take, TakeLast, TakeWhile, TakeLastWhile, Drop, DropLast, DropWhile and
+//     DropLastWhile
+//   - WindowedView and ChunkedView, and the windows that WindowedTransform
+//     passes to its function
+//   - the InPlace functions
+//   - AsMultimap, which returns the given map itself as a Multimap
+//   - Multimap.Get, which returns a view of the values of a key, and
+//     SetMultimap.Get, which returns the set held for a key that has values
+//
+// Writing to an element of a view writes to the input and vice versa. Views
+// have their capacity limited to their length, so appending to a view copies
+// it rather than overwriting the input. Each of the Take and Drop functions
+// has a Cloned variant, such as TakeCloned, that returns a copy instead.
+package fun
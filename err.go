@@ -0,0 +1,343 @@
+package fun
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ElementError records the failure of a callback for the element at Index in
+// the input slice. The "ErrAll" functions join one ElementError for every
+// failing element.
+type ElementError struct {
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// KeyError records the failure of a callback for the map entry with Key.
+// TransformMapErrAll joins one KeyError for every failing entry.
+type KeyError[K comparable] struct {
+	Key K
+	Err error
+}
+
+func (e *KeyError[K]) Error() string {
+	return fmt.Sprintf("key %v: %v", e.Key, e.Err)
+}
+
+func (e *KeyError[K]) Unwrap() error {
+	return e.Err
+}
+
+// AssociateErr returns a map containing key-value pairs returned by the given
+// function applied to the elements of the given slice. It stops at the first
+// error and returns it along with a nil map.
+func AssociateErr[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V, error),
+) (map[K]V, error) {
+	ret := make(map[K]V)
+	for _, e := range s {
+		k, v, err := fn(e)
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+// AssociateErrAll is like AssociateErr, but applies the function to every
+// element. Elements for which it fails are left out of the map, and the
+// returned error joins an ElementError for each of them.
+func AssociateErrAll[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V, error),
+) (map[K]V, error) {
+	ret := make(map[K]V)
+	var errs []error
+	for i, e := range s {
+		k, v, err := fn(e)
+		if err != nil {
+			errs = append(errs, &ElementError{i, err})
+			continue
+		}
+		ret[k] = v
+	}
+	return ret, errors.Join(errs...)
+}
+
+// FilterErr returns the slice obtained after retaining only those elements in
+// the given slice for which the given function returns true. It stops at the
+// first error and returns it along with a nil slice.
+func FilterErr[T any](s []T, fn func(T) (bool, error)) ([]T, error) {
+	return FilterMapErr(s, func(e T) (T, bool, error) {
+		ok, err := fn(e)
+		return e, ok, err
+	})
+}
+
+// FilterErrAll is like FilterErr, but applies the function to every element.
+// Elements for which it fails are dropped, and the returned error joins an
+// ElementError for each of them.
+func FilterErrAll[T any](s []T, fn func(T) (bool, error)) ([]T, error) {
+	return FilterMapErrAll(s, func(e T) (T, bool, error) {
+		ok, err := fn(e)
+		return e, ok, err
+	})
+}
+
+// FilterMapErr returns the slice obtained after both filtering and mapping
+// using the given function. It stops at the first error and returns it along
+// with a nil slice.
+func FilterMapErr[T1, T2 any](
+	s []T1,
+	fn func(T1) (T2, bool, error),
+) ([]T2, error) {
+	ret := make([]T2, 0)
+	for _, e := range s {
+		m, ok, err := fn(e)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret = append(ret, m)
+		}
+	}
+	return ret, nil
+}
+
+// FilterMapErrAll is like FilterMapErr, but applies the function to every
+// element. Elements for which it fails are dropped, and the returned error
+// joins an ElementError for each of them.
+func FilterMapErrAll[T1, T2 any](
+	s []T1,
+	fn func(T1) (T2, bool, error),
+) ([]T2, error) {
+	ret := make([]T2, 0)
+	var errs []error
+	for i, e := range s {
+		m, ok, err := fn(e)
+		if err != nil {
+			errs = append(errs, &ElementError{i, err})
+			continue
+		}
+		if ok {
+			ret = append(ret, m)
+		}
+	}
+	return ret, errors.Join(errs...)
+}
+
+// FoldErr accumulates values starting with given initial value and applying
+// given function to current accumulator and each element. It stops at the
+// first error and returns it along with the accumulator as it was before the
+// failing element.
+func FoldErr[T, R any](s []T, initial R, fn func(R, T) (R, error)) (R, error) {
+	acc := initial
+	for _, e := range s {
+		next, err := fn(acc, e)
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// FoldErrAll is like FoldErr, but applies the function to every element.
+// Elements for which it fails do not contribute to the accumulator, and the
+// returned error joins an ElementError for each of them.
+func FoldErrAll[T, R any](s []T, initial R, fn func(R, T) (R, error)) (R, error) {
+	acc := initial
+	var errs []error
+	for i, e := range s {
+		next, err := fn(acc, e)
+		if err != nil {
+			errs = append(errs, &ElementError{i, err})
+			continue
+		}
+		acc = next
+	}
+	return acc, errors.Join(errs...)
+}
+
+// ForEachErr invokes the given function for each element of the given slice,
+// stopping at the first error and returning it.
+func ForEachErr[T any](s []T, fn func(T) error) error {
+	for _, e := range s {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachErrAll invokes the given function for each element of the given
+// slice. The returned error joins an ElementError for every failure.
+func ForEachErrAll[T any](s []T, fn func(T) error) error {
+	var errs []error
+	for i, e := range s {
+		if err := fn(e); err != nil {
+			errs = append(errs, &ElementError{i, err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GroupByErr returns a map containing key to list of values returned by the
+// given function applied to the elements of the given slice. It stops at the
+// first error and returns it along with a nil map.
+func GroupByErr[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V, error),
+) (map[K][]V, error) {
+	ret := make(map[K][]V)
+	for _, e := range s {
+		k, v, err := fn(e)
+		if err != nil {
+			return nil, err
+		}
+		AppendToGroup(ret, k, v)
+	}
+	return ret, nil
+}
+
+// GroupByErrAll is like GroupByErr, but applies the function to every element.
+// Elements for which it fails are left out of the groups, and the returned
+// error joins an ElementError for each of them.
+func GroupByErrAll[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V, error),
+) (map[K][]V, error) {
+	ret := make(map[K][]V)
+	var errs []error
+	for i, e := range s {
+		k, v, err := fn(e)
+		if err != nil {
+			errs = append(errs, &ElementError{i, err})
+			continue
+		}
+		AppendToGroup(ret, k, v)
+	}
+	return ret, errors.Join(errs...)
+}
+
+// MapErr returns the slice obtained after applying the given function over
+// every element in the given slice. It stops at the first error and returns it
+// along with a nil slice.
+func MapErr[T1, T2 any](s []T1, fn func(T1) (T2, error)) ([]T2, error) {
+	ret := make([]T2, 0, len(s))
+	for _, e := range s {
+		m, err := fn(e)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, m)
+	}
+	return ret, nil
+}
+
+// MapErrAll is like MapErr, but applies the function to every element.
+// Elements for which it fails are dropped, and the returned error joins an
+// ElementError for each of them.
+func MapErrAll[T1, T2 any](s []T1, fn func(T1) (T2, error)) ([]T2, error) {
+	return FilterMapErrAll(s, func(e T1) (T2, bool, error) {
+		m, err := fn(e)
+		return m, true, err
+	})
+}
+
+// TransformMapErr applies the given function to each key, value in the map,
+// and returns a new map of the same type after transforming the keys and
+// values depending on the callback functions return values. If the bool return
+// value is false, the entry is dropped. It stops at the first error and
+// returns it along with a nil map. Entries are visited in the fixed order
+// described at itemsInKeyOrder, so the same entry's error is returned on every
+// run.
+func TransformMapErr[M ~map[K]V, K comparable, V any](
+	m M,
+	fn func(k K, v V) (K, V, bool, error),
+) (M, error) {
+	ret := make(map[K]V)
+	for _, p := range itemsInKeyOrder(m) {
+		newK, newV, include, err := fn(p.Fst, p.Snd)
+		if err != nil {
+			return nil, err
+		}
+		if include {
+			ret[newK] = newV
+		}
+	}
+	return ret, nil
+}
+
+// TransformMapErrAll is like TransformMapErr, but applies the function to every
+// entry. Entries for which it fails are dropped, and the returned error joins
+// a KeyError for each of them, in the same fixed order of their keys.
+func TransformMapErrAll[M ~map[K]V, K comparable, V any](
+	m M,
+	fn func(k K, v V) (K, V, bool, error),
+) (M, error) {
+	ret := make(map[K]V)
+	var errs []error
+	for _, p := range itemsInKeyOrder(m) {
+		newK, newV, include, err := fn(p.Fst, p.Snd)
+		if err != nil {
+			errs = append(errs, &KeyError[K]{p.Fst, err})
+			continue
+		}
+		if include {
+			ret[newK] = newV
+		}
+	}
+	return ret, errors.Join(errs...)
+}
+
+// itemsInKeyOrder returns the entries of the given map in a fixed order of
+// their keys, for functions that must not depend on Go's random map order but
+// only require comparable keys. Numbers, strings and booleans are in their
+// natural order; other keys are ordered by their kind and then by their %#v
+// form, which is fixed for everything but pointers and channels.
+func itemsInKeyOrder[M ~map[K]V, K comparable, V any](m M) []*Pair[K, V] {
+	ret := Items(m)
+	slices.SortFunc(ret, func(a, b *Pair[K, V]) int { return compareKeys(a.Fst, b.Fst) })
+	return ret
+}
+
+func compareKeys[K comparable](a, b K) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != vb.Kind() {
+		return cmp.Compare(va.Kind(), vb.Kind())
+	}
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	case reflect.Bool:
+		return cmp.Compare(boolRank(va.Bool()), boolRank(vb.Bool()))
+	}
+	return cmp.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
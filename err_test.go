@@ -0,0 +1,239 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var errOdd = errors.New("odd")
+
+func failOdd(i int) error {
+	if i%2 != 0 {
+		return errOdd
+	}
+	return nil
+}
+
+// elementIndices returns the indices of all ElementErrors joined in err
+func elementIndices(err error) []int {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	ret := make([]int, 0)
+	for _, e := range joined.Unwrap() {
+		var ee *ElementError
+		if errors.As(e, &ee) {
+			ret = append(ret, ee.Index)
+		}
+	}
+	return ret
+}
+
+func TestMapErr(t *testing.T) {
+	got, err := MapErr([]string{"1", "2", "3"}, strconv.Atoi)
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("MapErr() = %v, %v, want [1 2 3], nil", got, err)
+	}
+
+	calls := 0
+	got, err = MapErr([]string{"1", "x", "3", "y"}, func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	})
+	if err == nil || got != nil {
+		t.Errorf("MapErr() = %v, %v, want nil, error", got, err)
+	}
+	if calls != 2 {
+		t.Errorf("MapErr() made %d calls, want 2", calls)
+	}
+}
+
+func TestMapErrAll(t *testing.T) {
+	got, err := MapErrAll([]string{"1", "x", "3", "y"}, strconv.Atoi)
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("MapErrAll() = %v, want [1 3]", got)
+	}
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{1, 3}) {
+		t.Errorf("MapErrAll() failed indices = %v, want [1 3]", idx)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("MapErrAll() error %v does not wrap strconv.ErrSyntax", err)
+	}
+
+	got, err = MapErrAll([]string{"1", "2"}, strconv.Atoi)
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("MapErrAll() = %v, %v, want [1 2], nil", got, err)
+	}
+}
+
+func TestFilterErr(t *testing.T) {
+	pred := func(i int) (bool, error) {
+		if i < 0 {
+			return false, fmt.Errorf("negative: %d", i)
+		}
+		return i%2 == 0, nil
+	}
+	got, err := FilterErr([]int{1, 2, 3, 4}, pred)
+	if err != nil || !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Errorf("FilterErr() = %v, %v, want [2 4], nil", got, err)
+	}
+	got, err = FilterErr([]int{1, 2, -3, 4}, pred)
+	if err == nil || got != nil {
+		t.Errorf("FilterErr() = %v, %v, want nil, error", got, err)
+	}
+	got, err = FilterErrAll([]int{1, 2, -3, 4, -5}, pred)
+	if !reflect.DeepEqual(got, []int{2, 4}) {
+		t.Errorf("FilterErrAll() = %v, want [2 4]", got)
+	}
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{2, 4}) {
+		t.Errorf("FilterErrAll() failed indices = %v, want [2 4]", idx)
+	}
+}
+
+func TestFilterMapErr(t *testing.T) {
+	fn := func(s string) (int, bool, error) {
+		i, err := strconv.Atoi(s)
+		return i * i, i%2 == 0, err
+	}
+	got, err := FilterMapErr([]string{"1", "2", "3", "4"}, fn)
+	if err != nil || !reflect.DeepEqual(got, []int{4, 16}) {
+		t.Errorf("FilterMapErr() = %v, %v, want [4 16], nil", got, err)
+	}
+	got, err = FilterMapErrAll([]string{"1", "2", "z", "4"}, fn)
+	if !reflect.DeepEqual(got, []int{4, 16}) {
+		t.Errorf("FilterMapErrAll() = %v, want [4 16]", got)
+	}
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{2}) {
+		t.Errorf("FilterMapErrAll() failed indices = %v, want [2]", idx)
+	}
+}
+
+func TestFoldErr(t *testing.T) {
+	sum := func(acc, i int) (int, error) {
+		if err := failOdd(i); err != nil {
+			return 0, err
+		}
+		return acc + i, nil
+	}
+	got, err := FoldErr([]int{2, 4, 5, 6}, 0, sum)
+	if !errors.Is(err, errOdd) || got != 6 {
+		t.Errorf("FoldErr() = %v, %v, want 6, %v", got, err, errOdd)
+	}
+	got, err = FoldErrAll([]int{2, 4, 5, 6, 7}, 0, sum)
+	if got != 12 {
+		t.Errorf("FoldErrAll() = %v, want 12", got)
+	}
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{2, 4}) {
+		t.Errorf("FoldErrAll() failed indices = %v, want [2 4]", idx)
+	}
+}
+
+func TestForEachErr(t *testing.T) {
+	var seen []int
+	err := ForEachErr([]int{2, 4, 5, 6}, func(i int) error {
+		seen = append(seen, i)
+		return failOdd(i)
+	})
+	if !errors.Is(err, errOdd) || !reflect.DeepEqual(seen, []int{2, 4, 5}) {
+		t.Errorf("ForEachErr() = %v after %v, want %v after [2 4 5]", err, seen, errOdd)
+	}
+	err = ForEachErrAll([]int{1, 2, 3}, failOdd)
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{0, 2}) {
+		t.Errorf("ForEachErrAll() failed indices = %v, want [0 2]", idx)
+	}
+	if err := ForEachErrAll([]int{2, 4}, failOdd); err != nil {
+		t.Errorf("ForEachErrAll() = %v, want nil", err)
+	}
+}
+
+func TestGroupByAndAssociateErr(t *testing.T) {
+	fn := func(s string) (int, string, error) {
+		if s == "" {
+			return 0, s, errors.New("empty")
+		}
+		return len(s), s, nil
+	}
+	input := []string{"a", "bb", "", "cc", "d"}
+
+	if got, err := GroupByErr(input, fn); err == nil || got != nil {
+		t.Errorf("GroupByErr() = %v, %v, want nil, error", got, err)
+	}
+	groups, err := GroupByErrAll(input, fn)
+	wantGroups := map[int][]string{1: {"a", "d"}, 2: {"bb", "cc"}}
+	if !reflect.DeepEqual(groups, wantGroups) {
+		t.Errorf("GroupByErrAll() = %v, want %v", groups, wantGroups)
+	}
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{2}) {
+		t.Errorf("GroupByErrAll() failed indices = %v, want [2]", idx)
+	}
+
+	if got, err := AssociateErr(input, fn); err == nil || got != nil {
+		t.Errorf("AssociateErr() = %v, %v, want nil, error", got, err)
+	}
+	assoc, err := AssociateErrAll(input, fn)
+	wantAssoc := map[int]string{1: "d", 2: "cc"}
+	if !reflect.DeepEqual(assoc, wantAssoc) {
+		t.Errorf("AssociateErrAll() = %v, want %v", assoc, wantAssoc)
+	}
+	if idx := elementIndices(err); !reflect.DeepEqual(idx, []int{2}) {
+		t.Errorf("AssociateErrAll() failed indices = %v, want [2]", idx)
+	}
+	assoc, err = AssociateErr([]string{"a", "bb"}, fn)
+	if err != nil || !reflect.DeepEqual(assoc, map[int]string{1: "a", 2: "bb"}) {
+		t.Errorf("AssociateErr() = %v, %v", assoc, err)
+	}
+}
+
+func TestTransformMapErr(t *testing.T) {
+	m := map[string]string{"a": "1", "b": "x", "c": "3"}
+	fn := func(k, v string) (string, string, bool, error) {
+		if _, err := strconv.Atoi(v); err != nil {
+			return k, v, false, err
+		}
+		return k + k, v, true, nil
+	}
+	if got, err := TransformMapErr(m, fn); err == nil || got != nil {
+		t.Errorf("TransformMapErr() = %v, %v, want nil, error", got, err)
+	}
+	got, err := TransformMapErrAll(m, fn)
+	want := map[string]string{"aa": "1", "cc": "3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TransformMapErrAll() = %v, want %v", got, want)
+	}
+	var ke *KeyError[string]
+	if !errors.As(err, &ke) || ke.Key != "b" {
+		t.Errorf("TransformMapErrAll() error = %v, want KeyError for b", err)
+	}
+
+	// with several failing entries, the error does not depend on map order
+	m = map[string]string{"a": "1", "e": "y", "b": "x", "d": "4", "c": "z"}
+	for range 20 {
+		_, err := TransformMapErr(m, fn)
+		if want := `strconv.Atoi: parsing "x": invalid syntax`; err == nil || err.Error() != want {
+			t.Fatalf("TransformMapErr() error = %v, want %s", err, want)
+		}
+		_, err = TransformMapErrAll(m, fn)
+		want := `key b: strconv.Atoi: parsing "x": invalid syntax` + "\n" +
+			`key c: strconv.Atoi: parsing "z": invalid syntax` + "\n" +
+			`key e: strconv.Atoi: parsing "y": invalid syntax`
+		if err == nil || err.Error() != want {
+			t.Fatalf("TransformMapErrAll() error = %v, want %s", err, want)
+		}
+	}
+	ints := map[int]string{10: "x", 9: "y", -1: "z", 2: "2"}
+	_, err = TransformMapErrAll(ints, func(k int, v string) (int, string, bool, error) {
+		_, err := strconv.Atoi(v)
+		return k, v, err == nil, err
+	})
+	keys := []int{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		keys = append(keys, e.(*KeyError[int]).Key)
+	}
+	if want := []int{-1, 9, 10}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("TransformMapErrAll() failed keys = %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,179 @@
+//go:build ignore
+
+// This program generates tuple_gen.go: the tuple types of arity 3 and up,
+// with their Zip, ZipWith and Unzip functions. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const maxArity = 5
+
+// tuple describes the generated code for one arity
+type tuple struct {
+	N    int
+	Name string
+	// Doc is the phrase used for the tuple type in doc comments
+	Doc    string
+	Fields []field
+	// HasType is false for arity 2, whose Pair type, Zip and Unzip are
+	// written by hand in slice.go
+	HasType bool
+}
+
+var (
+	names  = []string{"", "", "Pair", "Triple", "Quad", "Quint"}
+	docs   = []string{"", "", "pair", "triple", "quadruple", "quintuple"}
+	counts = []string{"", "", "two", "three", "four", "five"}
+	fields = []string{"Fst", "Snd", "Thd", "Fth", "Fif"}
+)
+
+// field is a tuple field and the index of its type parameter
+type field struct {
+	Name string
+	N    int
+}
+
+func (t tuple) Suffix() string {
+	if t.N == 2 {
+		return ""
+	}
+	return fmt.Sprint(t.N)
+}
+
+func (t tuple) Count() string { return counts[t.N] }
+
+// AllSlices returns the phrase naming all the input slices
+func (t tuple) AllSlices() string {
+	if t.N == 2 {
+		return "both slices"
+	}
+	return "all " + counts[t.N] + " slices"
+}
+
+// Join formats each index from 1 to N, replacing # in the given format, and
+// joins the results with the given separator
+func (t tuple) Join(format, sep string) string {
+	parts := make([]string, t.N)
+	for i := range parts {
+		parts[i] = strings.ReplaceAll(format, "#", fmt.Sprint(i+1))
+	}
+	return strings.Join(parts, sep)
+}
+
+// TypeParams returns "T1, T2, ..."
+func (t tuple) TypeParams() string { return t.Join("T#", ", ") }
+
+// Slices returns "s1 []T1, s2 []T2, ..."
+func (t tuple) Slices() string { return t.Join("s# []T#", ", ") }
+
+// SliceTypes returns "[]T1, []T2, ..."
+func (t tuple) SliceTypes() string { return t.Join("[]T#", ", ") }
+
+// SliceNames returns "s1, s2, ..."
+func (t tuple) SliceNames() string { return t.Join("s#", ", ") }
+
+// Elements returns "s1[i], s2[i], ..."
+func (t tuple) Elements() string { return t.Join("s#[i]", ", ") }
+
+// Verbs returns the String format of the tuple, "(%v, %v, ...)"
+func (t tuple) Verbs() string { return "(" + t.Join("%v", ", ") + ")" }
+
+// FieldList returns "t.Fst, t.Snd, ..."
+func (t tuple) FieldList() string {
+	parts := make([]string, len(t.Fields))
+	for i, f := range t.Fields {
+		parts[i] = "t." + f.Name
+	}
+	return strings.Join(parts, ", ")
+}
+
+var tmpl = template.Must(template.New("file").Parse(`
+{{- define "tuple" -}}
+{{if .HasType}}
+// {{.Name}} represents a generic {{.Doc}} of {{.Count}} values
+type {{.Name}}[{{.TypeParams}} any] struct {
+{{- range .Fields}}
+	{{.Name}} T{{.N}}
+{{- end}}
+}
+
+func (t {{.Name}}[{{.TypeParams}}]) String() string {
+	return fmt.Sprintf("{{.Verbs}}", {{.FieldList}})
+}
+
+// Zip{{.Suffix}} returns a slice of {{.Doc}}s from the elements of
+// {{.AllSlices}} with the same index. The returned slice has the length of the
+// shortest input slice.
+func Zip{{.Suffix}}[{{.TypeParams}} any]({{.Slices}}) []*{{.Name}}[{{.TypeParams}}] {
+	n := min({{.Join "len(s#)" ", "}})
+	ret := make([]*{{.Name}}[{{.TypeParams}}], 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, &{{.Name}}[{{.TypeParams}}]{ {{- .Elements -}} })
+	}
+	return ret
+}
+
+// Unzip{{.Suffix}} returns {{.Count}} slices, where the n-th slice is built from
+// the n-th values of each {{.Doc}} from the input slice
+func Unzip{{.Suffix}}[{{.TypeParams}} any](ts []*{{.Name}}[{{.TypeParams}}]) ({{.SliceTypes}}) {
+{{- range .Fields}}
+	s{{.N}} := make([]T{{.N}}, 0, len(ts))
+{{- end}}
+	for _, t := range ts {
+{{- range .Fields}}
+		s{{.N}} = append(s{{.N}}, t.{{.Name}})
+{{- end}}
+	}
+	return {{.SliceNames}}
+}
+{{end}}
+// ZipWith{{.Suffix}} returns a slice of the results of applying the given function
+// to the elements of {{.AllSlices}} with the same index, without
+// allocating a {{.Doc}} for each of them. The returned slice has the length of
+// the shortest input slice.
+func ZipWith{{.Suffix}}[{{.TypeParams}}, R any]({{.Slices}}, fn func({{.TypeParams}}) R) []R {
+	n := min({{.Join "len(s#)" ", "}})
+	ret := make([]R, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, fn({{.Elements}}))
+	}
+	return ret
+}
+{{end}}
+{{- /* top level */ -}}
+// Code generated by gen_tuple.go; DO NOT EDIT.
+
+package fun
+
+import "fmt"
+{{range .}}{{template "tuple" .}}{{end}}`))
+
+func main() {
+	var tuples []tuple
+	for n := 2; n <= maxArity; n++ {
+		t := tuple{N: n, Name: names[n], Doc: docs[n], HasType: n > 2}
+		for i := range n {
+			t.Fields = append(t.Fields, field{fields[i], i + 1})
+		}
+		tuples = append(tuples, t)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tuples); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile("tuple_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
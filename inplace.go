@@ -0,0 +1,75 @@
+package fun
+
+// The in-place functions below reuse the backing array of the given slice
+// instead of allocating a new one. They return the resulting slice, which
+// shares that array with the input, so the input slice must not be used
+// afterwards. As with slices.DeleteFunc, the elements between the new length
+// and the old one are zeroed, so any pointers they held can be collected.
+
+// FilterInPlace is like Filter, but moves the retained elements to the front
+// of the given slice and returns it shortened to their number
+func FilterInPlace[T any](s []T, fn func(T) bool) []T {
+	return FilterIndexedInPlace(s, func(_ int, e T) bool { return fn(e) })
+}
+
+// FilterIndexedInPlace is like FilterIndexed, but moves the retained elements
+// to the front of the given slice and returns it shortened to their number.
+// The predicate receives each element's index in the original slice.
+func FilterIndexedInPlace[T any](s []T, fn func(int, T) bool) []T {
+	n := 0
+	for i, e := range s {
+		if fn(i, e) {
+			s[n] = e
+			n++
+		}
+	}
+	clear(s[n:])
+	return s[:n]
+}
+
+// DistinctInPlace is like Distinct, but moves the distinct elements to the
+// front of the given slice and returns it shortened to their number. Only the
+// set of elements seen so far is allocated.
+func DistinctInPlace[T comparable](s []T) []T {
+	seen := make(Set[T])
+	return FilterInPlace(s, func(e T) bool {
+		if seen.Contains(e) {
+			return false
+		}
+		seen.Add(e)
+		return true
+	})
+}
+
+// DistinctByInPlace is like DistinctBy, but moves the distinct elements to the
+// front of the given slice and returns it shortened to their number. Only the
+// set of keys seen so far is allocated.
+func DistinctByInPlace[T any, K comparable](s []T, fn func(T) K) []T {
+	seen := make(Set[K])
+	return FilterInPlace(s, func(e T) bool {
+		k := fn(e)
+		if seen.Contains(k) {
+			return false
+		}
+		seen.Add(k)
+		return true
+	})
+}
+
+// MapInPlace replaces every element of the given slice with the result of
+// applying the given function to it, and returns the slice
+func MapInPlace[T any](s []T, fn func(T) T) []T {
+	for i, e := range s {
+		s[i] = fn(e)
+	}
+	return s
+}
+
+// MapIndexedInPlace is like MapInPlace, but the function also receives the
+// index of each element in the slice
+func MapIndexedInPlace[T any](s []T, fn func(int, T) T) []T {
+	for i, e := range s {
+		s[i] = fn(i, e)
+	}
+	return s
+}
@@ -0,0 +1,85 @@
+package fun
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFilterInPlace(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	got := FilterInPlace(s, func(i int) bool { return i%2 == 0 })
+	if want := []int{2, 4, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterInPlace() = %v, want %v", got, want)
+	}
+	if &got[0] != &s[0] {
+		t.Errorf("FilterInPlace() did not reuse the backing array")
+	}
+	if want := []int{2, 4, 6, 0, 0, 0}; !reflect.DeepEqual(s, want) {
+		t.Errorf("FilterInPlace() left the input as %v, want %v", s, want)
+	}
+	if got := FilterInPlace([]int{}, func(int) bool { return true }); len(got) != 0 {
+		t.Errorf("FilterInPlace(empty) = %v, want []", got)
+	}
+	if got := FilterInPlace([]int(nil), func(int) bool { return true }); got != nil {
+		t.Errorf("FilterInPlace(nil) = %#v, want nil", got)
+	}
+}
+
+func TestFilterIndexedInPlace(t *testing.T) {
+	s := []string{"a", "b", "c", "d", "e"}
+	got := FilterIndexedInPlace(s, func(i int, _ string) bool { return i%2 == 0 })
+	if want := []string{"a", "c", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterIndexedInPlace() = %v, want %v", got, want)
+	}
+	if s[3] != "" || s[4] != "" {
+		t.Errorf("FilterIndexedInPlace() did not zero the tail: %q", s)
+	}
+}
+
+func TestDistinctInPlace(t *testing.T) {
+	s := []int{3, 1, 3, 2, 1}
+	got := DistinctInPlace(s)
+	if want := []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DistinctInPlace() = %v, want %v", got, want)
+	}
+	if want := []int{3, 1, 2, 0, 0}; !reflect.DeepEqual(s, want) {
+		t.Errorf("DistinctInPlace() left the input as %v, want %v", s, want)
+	}
+	words := []*string{new(string), new(string), new(string)}
+	*words[0], *words[1], *words[2] = "Go", "go", "Rust"
+	gotWords := DistinctByInPlace(words, func(w *string) string { return strings.ToLower(*w) })
+	if len(gotWords) != 2 || *gotWords[0] != "Go" || *gotWords[1] != "Rust" {
+		t.Errorf("DistinctByInPlace() = %v, want [Go Rust]", gotWords)
+	}
+	// the dropped pointer is released
+	if words[2] != nil {
+		t.Errorf("DistinctByInPlace() did not zero the tail: %v", words)
+	}
+}
+
+func TestMapInPlace(t *testing.T) {
+	s := []int{1, 2, 3}
+	got := MapInPlace(s, func(i int) int { return i * i })
+	if want := []int{1, 4, 9}; !reflect.DeepEqual(got, want) || !reflect.DeepEqual(s, want) {
+		t.Errorf("MapInPlace() = %v, input %v, want %v", got, s, want)
+	}
+	got = MapIndexedInPlace(s, func(i, e int) int { return i + e })
+	if want := []int{1, 5, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapIndexedInPlace() = %v, want %v", got, want)
+	}
+}
+
+func TestInPlaceAllocs(t *testing.T) {
+	s := make([]int, 1000)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := range s {
+			s[i] = i
+		}
+		FilterInPlace(s, func(i int) bool { return i%3 == 0 })
+		MapInPlace(s, func(i int) int { return i + 1 })
+	})
+	if allocs != 0 {
+		t.Errorf("FilterInPlace and MapInPlace allocated %v times, want 0", allocs)
+	}
+}
@@ -0,0 +1,87 @@
+package fun
+
+// The Into functions below write their results into storage provided by the
+// caller instead of allocating it, so that buffers can be reused across calls,
+// for instance from a sync.Pool. Like the built-in append, the slice variants
+// append to dst and return the updated slice, which must be used in place of
+// dst afterwards. Pass dst[:0] to overwrite earlier results. The map variants
+// add to the given map.
+
+// MapInto appends the results of applying the given function to every element
+// of the given slice to dst, and returns the updated slice
+func MapInto[T1, T2 any](dst []T2, s []T1, fn func(T1) T2) []T2 {
+	for _, e := range s {
+		dst = append(dst, fn(e))
+	}
+	return dst
+}
+
+// FilterInto appends the elements of the given slice for which the given
+// function returns true to dst, and returns the updated slice
+func FilterInto[T any](dst []T, s []T, fn func(T) bool) []T {
+	for _, e := range s {
+		if fn(e) {
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
+
+// FlatMapInto appends all the elements of the slices returned by the given
+// function applied to every element of the given slice to dst, and returns the
+// updated slice
+func FlatMapInto[T1, T2 any](dst []T2, s []T1, fn func(T1) []T2) []T2 {
+	for _, e := range s {
+		dst = append(dst, fn(e)...)
+	}
+	return dst
+}
+
+// GroupByInto adds the key-value pairs returned by the given function applied
+// to the elements of the given slice to dst, appending each value to the group
+// of its key. Use ClearGroups to empty the groups of dst while keeping their
+// storage.
+func GroupByInto[M ~map[K][]V, T, V any, K comparable](dst M, s []T, fn func(T) (K, V)) {
+	for _, e := range s {
+		k, v := fn(e)
+		AppendToGroup(dst, k, v)
+	}
+}
+
+// ClearGroups truncates every group of the given map to zero length, keeping
+// the keys and the storage of the groups for GroupByInto to reuse. Groups left
+// empty by a later GroupByInto stay in the map.
+func ClearGroups[M ~map[K][]V, K comparable, V any](m M) {
+	for k, vs := range m {
+		clear(vs)
+		m[k] = vs[:0]
+	}
+}
+
+// AssociateInto adds the key-value pairs returned by the given function applied
+// to the elements of the given slice to dst
+func AssociateInto[M ~map[K]V, T, V any, K comparable](dst M, s []T, fn func(T) (K, V)) {
+	for _, e := range s {
+		k, v := fn(e)
+		dst[k] = v
+	}
+}
+
+// ChunkedInto appends the chunks that Chunked would return to dst, and returns
+// the updated slice. Each chunk is copied into the slice already held past the
+// end of dst, within its capacity, if there is one, so calling it again with
+// dst[:0] reuses the chunks of the previous call. It panics if chunkSize is not
+// positive, like Chunked.
+func ChunkedInto[T any](dst [][]T, s []T, chunkSize int) [][]T {
+	mustCheck(checkSize(chunkSize))
+	for i := range windowCount(len(s), chunkSize) {
+		start := i * chunkSize
+		chunk := s[start : start+min(chunkSize, len(s)-start)]
+		var buf []T
+		if len(dst) < cap(dst) {
+			buf = dst[:len(dst)+1][len(dst)]
+		}
+		dst = append(dst, append(buf[:0], chunk...))
+	}
+	return dst
+}
@@ -0,0 +1,199 @@
+package fun
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMapFilterFlatMapInto(t *testing.T) {
+	dst := []string{"x"}
+	dst = MapInto(dst, []int{1, 2}, strconv.Itoa)
+	if want := []string{"x", "1", "2"}; !reflect.DeepEqual(dst, want) {
+		t.Errorf("MapInto() = %v, want %v", dst, want)
+	}
+	ints := FilterInto([]int{0}, []int{1, 2, 3, 4}, func(i int) bool { return i%2 == 0 })
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(ints, want) {
+		t.Errorf("FilterInto() = %v, want %v", ints, want)
+	}
+	ints = FlatMapInto(ints[:0], []int{1, 2}, func(i int) []int { return []int{i, i} })
+	if want := []int{1, 1, 2, 2}; !reflect.DeepEqual(ints, want) {
+		t.Errorf("FlatMapInto() = %v, want %v", ints, want)
+	}
+	if got := MapInto(nil, []int{}, strconv.Itoa); got != nil {
+		t.Errorf("MapInto(nil, empty) = %#v, want nil", got)
+	}
+}
+
+func TestGroupByInto(t *testing.T) {
+	byParity := func(i int) (bool, int) { return i%2 == 0, i }
+	dst := map[bool][]int{true: {0}}
+	GroupByInto(dst, []int{1, 2, 3, 4}, byParity)
+	if want := map[bool][]int{true: {0, 2, 4}, false: {1, 3}}; !reflect.DeepEqual(dst, want) {
+		t.Errorf("GroupByInto() = %v, want %v", dst, want)
+	}
+	ClearGroups(dst)
+	GroupByInto(dst, []int{6}, byParity)
+	if want := map[bool][]int{true: {6}, false: {}}; !reflect.DeepEqual(dst, want) {
+		t.Errorf("GroupByInto() after ClearGroups = %v, want %v", dst, want)
+	}
+}
+
+func TestAssociateInto(t *testing.T) {
+	dst := map[string]int{"a": 1}
+	AssociateInto(dst, []string{"bb", "ccc"}, func(s string) (string, int) { return s, len(s) })
+	if want := map[string]int{"a": 1, "bb": 2, "ccc": 3}; !reflect.DeepEqual(dst, want) {
+		t.Errorf("AssociateInto() = %v, want %v", dst, want)
+	}
+}
+
+func TestChunkedInto(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	dst := ChunkedInto(nil, s, 2)
+	if want := Chunked(s, 2); !reflect.DeepEqual(dst, want) {
+		t.Errorf("ChunkedInto() = %v, want %v", dst, want)
+	}
+	first := &dst[0][0]
+	dst = ChunkedInto(dst[:0], []int{6, 7, 8}, 2)
+	if want := [][]int{{6, 7}, {8}}; !reflect.DeepEqual(dst, want) {
+		t.Errorf("ChunkedInto() reusing dst = %v, want %v", dst, want)
+	}
+	if &dst[0][0] != first {
+		t.Errorf("ChunkedInto() did not reuse the chunk storage of dst")
+	}
+	// chunks are copies, not views into s
+	s[0] = 100
+	if dst = ChunkedInto(dst[:0], s, 5); dst[0][0] != 100 {
+		t.Errorf("ChunkedInto() = %v", dst)
+	}
+	s[0] = 1
+	if dst[0][0] != 100 {
+		t.Errorf("ChunkedInto() chunk aliases the input")
+	}
+	if dst = ChunkedInto(dst[:0], s, math.MaxInt); !reflect.DeepEqual(dst, [][]int{s}) {
+		t.Errorf("ChunkedInto(MaxInt) = %v, want %v", dst, [][]int{s})
+	}
+}
+
+var (
+	intoBenchInput = func() []int {
+		s := make([]int, 1000)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	}()
+	isEven = func(i int) bool { return i%2 == 0 }
+	double = func(i int) int { return i * 2 }
+	// self returns a subslice of the input, so that the callback itself does
+	// not allocate
+	self     = func(i int) []int { return intoBenchInput[i : i+1] }
+	modTen   = func(i int) (int, int) { return i % 10, i }
+	identity = func(i int) (int, int) { return i, i }
+)
+
+func TestIntoSteadyStateAllocs(t *testing.T) {
+	var ints []int
+	var chunks [][]int
+	groups := make(map[int][]int)
+	assoc := make(map[int]int)
+	run := func() {
+		ints = MapInto(ints[:0], intoBenchInput, double)
+		ints = FilterInto(ints[:0], intoBenchInput, isEven)
+		ints = FlatMapInto(ints[:0], intoBenchInput, self)
+		chunks = ChunkedInto(chunks[:0], intoBenchInput, 16)
+		ClearGroups(groups)
+		GroupByInto(groups, intoBenchInput, modTen)
+		AssociateInto(assoc, intoBenchInput, identity)
+	}
+	run()
+	if allocs := testing.AllocsPerRun(100, run); allocs != 0 {
+		t.Errorf("Into functions allocated %v times in steady state, want 0", allocs)
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Map(intoBenchInput, double)
+	}
+}
+
+func BenchmarkMapInto(b *testing.B) {
+	b.ReportAllocs()
+	var dst []int
+	for range b.N {
+		dst = MapInto(dst[:0], intoBenchInput, double)
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Filter(intoBenchInput, isEven)
+	}
+}
+
+func BenchmarkFilterInto(b *testing.B) {
+	b.ReportAllocs()
+	var dst []int
+	for range b.N {
+		dst = FilterInto(dst[:0], intoBenchInput, isEven)
+	}
+}
+
+func BenchmarkFlatMap(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		FlatMap(intoBenchInput, self)
+	}
+}
+
+func BenchmarkFlatMapInto(b *testing.B) {
+	b.ReportAllocs()
+	var dst []int
+	for range b.N {
+		dst = FlatMapInto(dst[:0], intoBenchInput, self)
+	}
+}
+
+func BenchmarkGroupBy(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		GroupBy(intoBenchInput, modTen)
+	}
+}
+
+func BenchmarkGroupByInto(b *testing.B) {
+	b.ReportAllocs()
+	dst := make(map[int][]int)
+	for range b.N {
+		ClearGroups(dst)
+		GroupByInto(dst, intoBenchInput, modTen)
+	}
+}
+
+func BenchmarkAssociate(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Associate(intoBenchInput, identity)
+	}
+}
+
+func BenchmarkAssociateInto(b *testing.B) {
+	b.ReportAllocs()
+	dst := make(map[int]int)
+	for range b.N {
+		clear(dst)
+		AssociateInto(dst, intoBenchInput, identity)
+	}
+}
+
+func BenchmarkChunkedInto(b *testing.B) {
+	b.ReportAllocs()
+	var dst [][]int
+	for range b.N {
+		dst = ChunkedInto(dst[:0], intoBenchInput, 100)
+	}
+}
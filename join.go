@@ -0,0 +1,323 @@
+package fun
+
+import "cmp"
+
+// InnerJoin returns a pair for every combination of elements of the left and
+// right slices whose keys, as returned by the given selector functions, are
+// equal. Pairs are ordered by the left element, then by the right element.
+func InnerJoin[L, R any, K comparable](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[L, R] {
+	return innerJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), newPair[L, R])
+}
+
+// InnerJoinWith is like InnerJoin, but returns the result of the given
+// function applied to each matching pair of elements
+func InnerJoinWith[L, R any, K comparable, V any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	fn func(L, R) V,
+) []V {
+	return innerJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), fn)
+}
+
+// LeftJoin is like InnerJoin, but also returns a pair with an empty right
+// Option for every left element without a match
+func LeftJoin[L, R any, K comparable](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[L, Option[R]] {
+	return leftJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), newPair[L, Option[R]])
+}
+
+// LeftJoinWith is like LeftJoin, but returns the result of the given function
+// applied to each pair
+func LeftJoinWith[L, R any, K comparable, V any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	fn func(L, Option[R]) V,
+) []V {
+	return leftJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), fn)
+}
+
+// RightJoin is like InnerJoin, but also returns a pair with an empty left
+// Option for every right element without a match. Matched pairs come first,
+// ordered by the left element, followed by the unmatched right elements in
+// their original order.
+func RightJoin[L, R any, K comparable](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[Option[L], R] {
+	return rightJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), newPair[Option[L], R])
+}
+
+// RightJoinWith is like RightJoin, but returns the result of the given
+// function applied to each pair
+func RightJoinWith[L, R any, K comparable, V any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	fn func(Option[L], R) V,
+) []V {
+	return rightJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), fn)
+}
+
+// FullOuterJoin combines LeftJoin and RightJoin: it returns the pairs of
+// LeftJoin, followed by a pair with an empty left Option for every unmatched
+// right element in its original order
+func FullOuterJoin[L, R any, K comparable](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[Option[L], Option[R]] {
+	return fullOuterJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), newPair[Option[L], Option[R]])
+}
+
+// FullOuterJoinWith is like FullOuterJoin, but returns the result of the given
+// function applied to each pair
+func FullOuterJoinWith[L, R any, K comparable, V any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	fn func(Option[L], Option[R]) V,
+) []V {
+	return fullOuterJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), fn)
+}
+
+// GroupJoin returns a pair for every left element, holding the right elements
+// with an equal key in their original order. Left elements without a match
+// are paired with an empty slice.
+func GroupJoin[L, R any, K comparable](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[L, []R] {
+	return groupJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), newPair[L, []R])
+}
+
+// GroupJoinWith is like GroupJoin, but returns the result of the given
+// function applied to each left element and its matches
+func GroupJoinWith[L, R any, K comparable, V any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	fn func(L, []R) V,
+) []V {
+	return groupJoin(left, right, hashJoinPlan(left, right, leftKey, rightKey), fn)
+}
+
+// MergeInnerJoin is like InnerJoin, but requires both slices to be sorted in
+// ascending order of their keys. It avoids building a hash table and produces
+// the same result as InnerJoin for such input.
+func MergeInnerJoin[L, R any, K cmp.Ordered](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[L, R] {
+	return innerJoin(left, right, mergeJoinPlan(left, right, leftKey, rightKey), newPair[L, R])
+}
+
+// MergeLeftJoin is like LeftJoin, but requires both slices to be sorted in
+// ascending order of their keys
+func MergeLeftJoin[L, R any, K cmp.Ordered](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[L, Option[R]] {
+	return leftJoin(left, right, mergeJoinPlan(left, right, leftKey, rightKey), newPair[L, Option[R]])
+}
+
+// MergeRightJoin is like RightJoin, but requires both slices to be sorted in
+// ascending order of their keys
+func MergeRightJoin[L, R any, K cmp.Ordered](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[Option[L], R] {
+	return rightJoin(left, right, mergeJoinPlan(left, right, leftKey, rightKey), newPair[Option[L], R])
+}
+
+// MergeFullOuterJoin is like FullOuterJoin, but requires both slices to be
+// sorted in ascending order of their keys
+func MergeFullOuterJoin[L, R any, K cmp.Ordered](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[Option[L], Option[R]] {
+	return fullOuterJoin(left, right, mergeJoinPlan(left, right, leftKey, rightKey), newPair[Option[L], Option[R]])
+}
+
+// MergeGroupJoin is like GroupJoin, but requires both slices to be sorted in
+// ascending order of their keys
+func MergeGroupJoin[L, R any, K cmp.Ordered](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) []*Pair[L, []R] {
+	return groupJoin(left, right, mergeJoinPlan(left, right, leftKey, rightKey), newPair[L, []R])
+}
+
+// joinPlan records which right elements match each left element, by index.
+// Both join strategies produce the same plan, from which every kind of join
+// is assembled.
+type joinPlan struct {
+	// matches[i] holds the indices of the right elements matching left[i],
+	// in ascending order
+	matches [][]int
+	// unmatched holds the indices of the right elements matching no left
+	// element, in ascending order
+	unmatched []int
+}
+
+// hashJoinPlan builds a plan by indexing the right slice by key
+func hashJoinPlan[L, R any, K comparable](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) joinPlan {
+	index := make(map[K][]int)
+	for j, r := range right {
+		AppendToGroup(index, rightKey(r), j)
+	}
+	matched := make([]bool, len(right))
+	plan := joinPlan{matches: make([][]int, len(left))}
+	for i, l := range left {
+		js := index[leftKey(l)]
+		plan.matches[i] = js
+		for _, j := range js {
+			matched[j] = true
+		}
+	}
+	for j, m := range matched {
+		if !m {
+			plan.unmatched = append(plan.unmatched, j)
+		}
+	}
+	return plan
+}
+
+// mergeJoinPlan builds a plan by walking both slices, which must be sorted by
+// key, in step
+func mergeJoinPlan[L, R any, K cmp.Ordered](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+) joinPlan {
+	plan := joinPlan{matches: make([][]int, len(left))}
+	leftKeys := Map(left, leftKey)
+	rightKeys := Map(right, rightKey)
+	j := 0
+	for i, k := range leftKeys {
+		for j < len(right) && cmp.Less(rightKeys[j], k) {
+			plan.unmatched = append(plan.unmatched, j)
+			j++
+		}
+		// the run of equal right keys starting at j matches this left element,
+		// and any following left elements with the same key
+		end := j
+		for end < len(right) && rightKeys[end] == k {
+			end++
+		}
+		if end > j {
+			run := make([]int, 0, end-j)
+			for m := j; m < end; m++ {
+				run = append(run, m)
+			}
+			plan.matches[i] = run
+			if i+1 == len(leftKeys) || leftKeys[i+1] != k {
+				j = end
+			}
+		}
+	}
+	for ; j < len(right); j++ {
+		plan.unmatched = append(plan.unmatched, j)
+	}
+	return plan
+}
+
+func newPair[T1, T2 any](a T1, b T2) *Pair[T1, T2] {
+	return &Pair[T1, T2]{a, b}
+}
+
+func innerJoin[L, R, V any](left []L, right []R, plan joinPlan, fn func(L, R) V) []V {
+	ret := make([]V, 0)
+	for i, js := range plan.matches {
+		for _, j := range js {
+			ret = append(ret, fn(left[i], right[j]))
+		}
+	}
+	return ret
+}
+
+func leftJoin[L, R, V any](left []L, right []R, plan joinPlan, fn func(L, Option[R]) V) []V {
+	ret := make([]V, 0, len(left))
+	for i, js := range plan.matches {
+		if len(js) == 0 {
+			ret = append(ret, fn(left[i], None[R]()))
+		}
+		for _, j := range js {
+			ret = append(ret, fn(left[i], Some(right[j])))
+		}
+	}
+	return ret
+}
+
+func rightJoin[L, R, V any](left []L, right []R, plan joinPlan, fn func(Option[L], R) V) []V {
+	ret := innerJoin(left, right, plan, func(l L, r R) V {
+		return fn(Some(l), r)
+	})
+	for _, j := range plan.unmatched {
+		ret = append(ret, fn(None[L](), right[j]))
+	}
+	return ret
+}
+
+func fullOuterJoin[L, R, V any](
+	left []L,
+	right []R,
+	plan joinPlan,
+	fn func(Option[L], Option[R]) V,
+) []V {
+	ret := leftJoin(left, right, plan, func(l L, r Option[R]) V {
+		return fn(Some(l), r)
+	})
+	for _, j := range plan.unmatched {
+		ret = append(ret, fn(None[L](), Some(right[j])))
+	}
+	return ret
+}
+
+func groupJoin[L, R, V any](left []L, right []R, plan joinPlan, fn func(L, []R) V) []V {
+	ret := make([]V, 0, len(left))
+	for i, js := range plan.matches {
+		group := make([]R, 0, len(js))
+		for _, j := range js {
+			group = append(group, right[j])
+		}
+		ret = append(ret, fn(left[i], group))
+	}
+	return ret
+}
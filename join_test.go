@@ -0,0 +1,158 @@
+package fun
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type joinUser struct {
+	id   int
+	name string
+}
+
+type joinOrder struct {
+	userID int
+	item   string
+}
+
+var (
+	joinUsers = []joinUser{{1, "ann"}, {2, "bob"}, {3, "cid"}, {3, "cat"}}
+	// orders are sorted by user id, so merge joins can use them as well
+	joinOrders = []joinOrder{{0, "ghost"}, {1, "pen"}, {1, "ink"}, {3, "cup"}, {4, "mug"}}
+	userID     = func(u joinUser) int { return u.id }
+	orderUser  = func(o joinOrder) int { return o.userID }
+)
+
+func showPairs[T1, T2 any](ps []*Pair[T1, T2]) []string {
+	return Map(ps, func(p *Pair[T1, T2]) string { return p.String() })
+}
+
+func TestInnerJoin(t *testing.T) {
+	want := []string{
+		"({1 ann}, {1 pen})",
+		"({1 ann}, {1 ink})",
+		"({3 cid}, {3 cup})",
+		"({3 cat}, {3 cup})",
+	}
+	if got := showPairs(InnerJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("InnerJoin() = %v, want %v", got, want)
+	}
+	if got := showPairs(MergeInnerJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeInnerJoin() = %v, want %v", got, want)
+	}
+	projected := InnerJoinWith(joinUsers, joinOrders, userID, orderUser, func(u joinUser, o joinOrder) string {
+		return u.name + ":" + o.item
+	})
+	if !reflect.DeepEqual(projected, []string{"ann:pen", "ann:ink", "cid:cup", "cat:cup"}) {
+		t.Errorf("InnerJoinWith() = %v", projected)
+	}
+	if got := InnerJoin([]joinUser{}, joinOrders, userID, orderUser); !reflect.DeepEqual(got, []*Pair[joinUser, joinOrder]{}) {
+		t.Errorf("InnerJoin(empty) = %v, want []", got)
+	}
+}
+
+func TestLeftJoin(t *testing.T) {
+	want := []string{
+		"({1 ann}, Some({1 pen}))",
+		"({1 ann}, Some({1 ink}))",
+		"({2 bob}, None)",
+		"({3 cid}, Some({3 cup}))",
+		"({3 cat}, Some({3 cup}))",
+	}
+	if got := showPairs(LeftJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("LeftJoin() = %v, want %v", got, want)
+	}
+	if got := showPairs(MergeLeftJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeLeftJoin() = %v, want %v", got, want)
+	}
+	projected := LeftJoinWith(joinUsers, joinOrders, userID, orderUser, func(u joinUser, o Option[joinOrder]) string {
+		return u.name + ":" + OptionMap(o, func(o joinOrder) string { return o.item }).OrElse("-")
+	})
+	if !reflect.DeepEqual(projected, []string{"ann:pen", "ann:ink", "bob:-", "cid:cup", "cat:cup"}) {
+		t.Errorf("LeftJoinWith() = %v", projected)
+	}
+}
+
+func TestRightJoin(t *testing.T) {
+	want := []string{
+		"(Some({1 ann}), {1 pen})",
+		"(Some({1 ann}), {1 ink})",
+		"(Some({3 cid}), {3 cup})",
+		"(Some({3 cat}), {3 cup})",
+		"(None, {0 ghost})",
+		"(None, {4 mug})",
+	}
+	if got := showPairs(RightJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("RightJoin() = %v, want %v", got, want)
+	}
+	if got := showPairs(MergeRightJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeRightJoin() = %v, want %v", got, want)
+	}
+	projected := RightJoinWith(joinUsers, joinOrders, userID, orderUser, func(u Option[joinUser], o joinOrder) bool {
+		return u.IsSome()
+	})
+	if !reflect.DeepEqual(projected, []bool{true, true, true, true, false, false}) {
+		t.Errorf("RightJoinWith() = %v", projected)
+	}
+}
+
+func TestFullOuterJoin(t *testing.T) {
+	want := []string{
+		"(Some({1 ann}), Some({1 pen}))",
+		"(Some({1 ann}), Some({1 ink}))",
+		"(Some({2 bob}), None)",
+		"(Some({3 cid}), Some({3 cup}))",
+		"(Some({3 cat}), Some({3 cup}))",
+		"(None, Some({0 ghost}))",
+		"(None, Some({4 mug}))",
+	}
+	if got := showPairs(FullOuterJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("FullOuterJoin() = %v, want %v", got, want)
+	}
+	if got := showPairs(MergeFullOuterJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeFullOuterJoin() = %v, want %v", got, want)
+	}
+	projected := FullOuterJoinWith(joinUsers, joinOrders, userID, orderUser, func(u Option[joinUser], o Option[joinOrder]) string {
+		return fmt.Sprint(u.IsSome(), o.IsSome())
+	})
+	if len(projected) != len(want) || projected[2] != "true false" || projected[6] != "false true" {
+		t.Errorf("FullOuterJoinWith() = %v", projected)
+	}
+}
+
+func TestGroupJoin(t *testing.T) {
+	want := []string{
+		"({1 ann}, [{1 pen} {1 ink}])",
+		"({2 bob}, [])",
+		"({3 cid}, [{3 cup}])",
+		"({3 cat}, [{3 cup}])",
+	}
+	if got := showPairs(GroupJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupJoin() = %v, want %v", got, want)
+	}
+	if got := showPairs(MergeGroupJoin(joinUsers, joinOrders, userID, orderUser)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeGroupJoin() = %v, want %v", got, want)
+	}
+	counts := GroupJoinWith(joinUsers, joinOrders, userID, orderUser, func(u joinUser, os []joinOrder) int {
+		return len(os)
+	})
+	if !reflect.DeepEqual(counts, []int{2, 0, 1, 1}) {
+		t.Errorf("GroupJoinWith() = %v", counts)
+	}
+}
+
+func TestMergeJoinMatchesHashJoin(t *testing.T) {
+	// sorted inputs with duplicate keys and gaps on both sides
+	left := []int{1, 1, 2, 4, 4, 4, 7, 9}
+	right := []int{0, 1, 3, 4, 4, 8, 9, 9, 10}
+	id := func(i int) int { return i }
+	if got, want := showPairs(MergeFullOuterJoin(left, right, id, id)),
+		showPairs(FullOuterJoin(left, right, id, id)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeFullOuterJoin() = %v, want %v", got, want)
+	}
+	if got, want := showPairs(MergeGroupJoin(left, right, id, id)),
+		showPairs(GroupJoin(left, right, id, id)); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeGroupJoin() = %v, want %v", got, want)
+	}
+}
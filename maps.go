@@ -0,0 +1,142 @@
+package fun
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Keys returns the keys of the given map, in no particular order
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	ret := make([]K, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+// Values returns the values of the given map, in no particular order
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	ret := make([]V, 0, len(m))
+	for _, v := range m {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// SortedKeys returns the keys of the given map in ascending order
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	ret := Keys(m)
+	slices.Sort(ret)
+	return ret
+}
+
+// SortedItems returns the (key, value) pairs of the given map as a slice, in
+// ascending order of their keys
+func SortedItems[M ~map[K]V, K cmp.Ordered, V any](m M) []*Pair[K, V] {
+	ret := Items(m)
+	slices.SortFunc(ret, func(a, b *Pair[K, V]) int { return cmp.Compare(a.Fst, b.Fst) })
+	return ret
+}
+
+// MapKeys returns a map with the same values as the given map, under the keys
+// returned by the given function applied to each key. If the function returns
+// the same key for several keys, one of their values is kept, in no
+// particular order.
+func MapKeys[M ~map[K1]V, K1, K2 comparable, V any](m M, fn func(K1) K2) map[K2]V {
+	ret := make(map[K2]V, len(m))
+	for k, v := range m {
+		ret[fn(k)] = v
+	}
+	return ret
+}
+
+// MapValues returns a map with the same keys as the given map, each holding
+// the result of applying the given function to its value
+func MapValues[M ~map[K]V1, K comparable, V1, V2 any](m M, fn func(V1) V2) map[K]V2 {
+	ret := make(map[K]V2, len(m))
+	for k, v := range m {
+		ret[k] = fn(v)
+	}
+	return ret
+}
+
+// FilterKeys returns a map with the entries of the given map whose keys
+// satisfy the given predicate
+func FilterKeys[M ~map[K]V, K comparable, V any](m M, fn func(K) bool) M {
+	ret := make(M)
+	for k, v := range m {
+		if fn(k) {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// FilterValues returns a map with the entries of the given map whose values
+// satisfy the given predicate
+func FilterValues[M ~map[K]V, K comparable, V any](m M, fn func(V) bool) M {
+	ret := make(M)
+	for k, v := range m {
+		if fn(v) {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// Invert returns a map from each value of the given map to its key. If several
+// keys have the same value, the given function is called with the value, the
+// key kept so far and the next key, and returns the key to keep. Keys are
+// visited in no particular order, so for a deterministic result the function
+// should not depend on the order of its arguments, like one that keeps the
+// smaller key.
+func Invert[M ~map[K]V, K, V comparable](m M, resolve func(v V, a, b K) K) map[V]K {
+	ret := make(map[V]K, len(m))
+	for k, v := range m {
+		if prev, ok := ret[v]; ok {
+			k = resolve(v, prev, k)
+		}
+		ret[v] = k
+	}
+	return ret
+}
+
+// InvertToGroup returns a map from each value of the given map to all the keys
+// that have it, in no particular order
+func InvertToGroup[M ~map[K]V, K, V comparable](m M) map[V][]K {
+	ret := make(map[V][]K)
+	for k, v := range m {
+		AppendToGroup(ret, v, k)
+	}
+	return ret
+}
+
+// PickKeys returns a map with the entries of the given map for the given keys.
+// Keys missing from the map are ignored.
+func PickKeys[M ~map[K]V, K comparable, V any](m M, keys ...K) M {
+	ret := make(M, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// OmitKeys returns a map with the entries of the given map except those for the
+// given keys
+func OmitKeys[M ~map[K]V, K comparable, V any](m M, keys ...K) M {
+	omit := NewSet(keys...)
+	return FilterKeys(m, func(k K) bool { return !omit.Contains(k) })
+}
+
+// FromPairs returns a map with the (key, value) pairs of the given slice, like
+// those returned by Items. If a key appears more than once, its last value is
+// kept.
+func FromPairs[K comparable, V any](ps []*Pair[K, V]) map[K]V {
+	ret := make(map[K]V, len(ps))
+	for _, p := range ps {
+		ret[p.Fst] = p.Snd
+	}
+	return ret
+}
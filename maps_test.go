@@ -0,0 +1,93 @@
+package fun
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var ages = map[string]int{"ann": 30, "bob": 25, "cid": 30, "dan": 41}
+
+func TestKeysValues(t *testing.T) {
+	keys := Keys(ages)
+	slices.Sort(keys)
+	if want := []string{"ann", "bob", "cid", "dan"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+	values := Values(ages)
+	slices.Sort(values)
+	if want := []int{25, 30, 30, 41}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Values() = %v, want %v", values, want)
+	}
+	if got := SortedKeys(ages); !reflect.DeepEqual(got, keys) {
+		t.Errorf("SortedKeys() = %v, want %v", got, keys)
+	}
+	items := SortedItems(ages)
+	if got := showPairs(items); !reflect.DeepEqual(got, []string{"(ann, 30)", "(bob, 25)", "(cid, 30)", "(dan, 41)"}) {
+		t.Errorf("SortedItems() = %v", got)
+	}
+	if got := FromPairs(items); !reflect.DeepEqual(got, ages) {
+		t.Errorf("FromPairs(SortedItems()) = %v, want %v", got, ages)
+	}
+}
+
+func TestFromPairs(t *testing.T) {
+	got := FromPairs([]*Pair[string, int]{{"a", 1}, {"b", 2}, {"a", 3}})
+	if want := map[string]int{"a": 3, "b": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FromPairs() = %v, want %v", got, want)
+	}
+	if got := FromPairs(Items(map[int]int{})); got == nil || len(got) != 0 {
+		t.Errorf("FromPairs(empty) = %#v, want empty map", got)
+	}
+}
+
+func TestMapKeysValues(t *testing.T) {
+	got := MapKeys(ages, strings.ToUpper)
+	if want := map[string]int{"ANN": 30, "BOB": 25, "CID": 30, "DAN": 41}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapKeys() = %v, want %v", got, want)
+	}
+	decades := MapValues(ages, func(a int) int { return a / 10 * 10 })
+	if want := map[string]int{"ann": 30, "bob": 20, "cid": 30, "dan": 40}; !reflect.DeepEqual(decades, want) {
+		t.Errorf("MapValues() = %v, want %v", decades, want)
+	}
+}
+
+func TestFilterKeysValues(t *testing.T) {
+	type ageMap map[string]int
+	m := ageMap(ages)
+	var got ageMap = FilterKeys(m, func(k string) bool { return k < "c" })
+	if want := (ageMap{"ann": 30, "bob": 25}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterKeys() = %v, want %v", got, want)
+	}
+	got = FilterValues(m, func(v int) bool { return v >= 30 })
+	if want := (ageMap{"ann": 30, "cid": 30, "dan": 41}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterValues() = %v, want %v", got, want)
+	}
+}
+
+func TestInvert(t *testing.T) {
+	got := Invert(ages, func(_ int, a, b string) string { return min(a, b) })
+	if want := map[int]string{30: "ann", 25: "bob", 41: "dan"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Invert() = %v, want %v", got, want)
+	}
+	groups := InvertToGroup(ages)
+	slices.Sort(groups[30])
+	if want := map[int][]string{30: {"ann", "cid"}, 25: {"bob"}, 41: {"dan"}}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("InvertToGroup() = %v, want %v", groups, want)
+	}
+}
+
+func TestPickOmitKeys(t *testing.T) {
+	got := PickKeys(ages, "ann", "dan", "zed")
+	if want := map[string]int{"ann": 30, "dan": 41}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PickKeys() = %v, want %v", got, want)
+	}
+	got = OmitKeys(ages, "ann", "dan", "zed")
+	if want := map[string]int{"bob": 25, "cid": 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OmitKeys() = %v, want %v", got, want)
+	}
+	if got := OmitKeys(ages); !reflect.DeepEqual(got, ages) {
+		t.Errorf("OmitKeys() with no keys = %v, want %v", got, ages)
+	}
+}
@@ -0,0 +1,195 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPathConflict is returned by Unflatten when a key is both a value and the
+// prefix of other keys, as with "a" and "a.b"
+var ErrPathConflict = errors.New("fun: conflicting keys")
+
+// Merge returns a map with the entries of all the given maps. If a key is in
+// more than one map, the given function is called with the key, the value
+// merged so far and the value from the later map, and returns the value to
+// keep. Maps are merged in argument order. A nil function keeps the value from
+// the last map.
+func Merge[M ~map[K]V, K comparable, V any](resolve func(k K, a, b V) V, maps ...M) M {
+	ret := make(M)
+	for _, m := range maps {
+		for k, v := range m {
+			if prev, ok := ret[k]; ok && resolve != nil {
+				v = resolve(k, prev, v)
+			}
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// MergeGroups returns a map with the groups of all the given maps, like those
+// returned by GroupBy. The values of a key that is in more than one map are
+// appended in argument order, as by AppendToGroup. The returned groups do not
+// share storage with the given ones.
+func MergeGroups[M ~map[K][]V, K comparable, V any](maps ...M) M {
+	ret := make(M)
+	for _, m := range maps {
+		for k, vs := range m {
+			group, ok := ret[k]
+			if !ok {
+				group = make([]V, 0, len(vs))
+			}
+			ret[k] = append(group, vs...)
+		}
+	}
+	return ret
+}
+
+// ListStrategy combines the lists found under the same path by DeepMerge. The
+// path is the dotted key of the list from the root of the tree.
+type ListStrategy func(path string, a, b []any) []any
+
+// ReplaceLists is a ListStrategy that keeps the list from the later tree
+func ReplaceLists(_ string, _, b []any) []any {
+	return cloned(b)
+}
+
+// AppendLists is a ListStrategy that appends the list from the later tree to
+// the one from the earlier tree
+func AppendLists(_ string, a, b []any) []any {
+	return append(cloned(a), b...)
+}
+
+// UnionListsBy returns a ListStrategy that identifies list elements by the
+// key returned by the given function, which must be comparable. Elements of
+// the later list replace the elements of the earlier list with the same key,
+// keeping their position, and the rest are appended in order.
+func UnionListsBy(key func(any) any) ListStrategy {
+	return func(_ string, a, b []any) []any {
+		ret := cloned(a)
+		index := make(map[any]int, len(a))
+		for i, e := range ret {
+			index[key(e)] = i
+		}
+		for _, e := range b {
+			k := key(e)
+			if i, ok := index[k]; ok {
+				ret[i] = e
+				continue
+			}
+			index[k] = len(ret)
+			ret = append(ret, e)
+		}
+		return ret
+	}
+}
+
+// DeepMerge returns the merge of the given trees, such as those decoded from
+// JSON, with the later trees taking precedence. Nested maps of type
+// map[string]any are merged recursively, lists of type []any are combined by
+// the given strategy, and any other value from a later tree replaces the one
+// from an earlier tree. A nil strategy replaces lists. The nested maps and
+// lists of the returned tree are copies, which do not share storage with the
+// given trees.
+func DeepMerge(lists ListStrategy, trees ...map[string]any) map[string]any {
+	if lists == nil {
+		lists = ReplaceLists
+	}
+	ret := make(map[string]any)
+	for _, t := range trees {
+		deepMergeInto(ret, t, "", lists)
+	}
+	return ret
+}
+
+func deepMergeInto(dst, src map[string]any, prefix string, lists ListStrategy) {
+	for k, v := range src {
+		path := prefix + k
+		switch v := v.(type) {
+		case map[string]any:
+			if d, ok := dst[k].(map[string]any); ok {
+				deepMergeInto(d, v, path+".", lists)
+				continue
+			}
+			d := make(map[string]any, len(v))
+			deepMergeInto(d, v, path+".", lists)
+			dst[k] = d
+		case []any:
+			if d, ok := dst[k].([]any); ok {
+				dst[k] = cloneTree(lists(path, d, v))
+			} else {
+				dst[k] = cloneTree(v)
+			}
+		default:
+			dst[k] = v
+		}
+	}
+}
+
+// cloneTree returns a deep copy of the maps and lists in the given value
+func cloneTree(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		ret := make(map[string]any, len(v))
+		for k, e := range v {
+			ret[k] = cloneTree(e)
+		}
+		return ret
+	case []any:
+		return Map(v, cloneTree)
+	default:
+		return v
+	}
+}
+
+// Flatten returns a map with the leaves of the given tree of nested
+// map[string]any values, keyed by their paths from the root joined with the
+// given separator. Lists and empty maps are leaves.
+func Flatten(tree map[string]any, sep string) map[string]any {
+	ret := make(map[string]any)
+	flattenInto(ret, tree, "", sep)
+	return ret
+}
+
+func flattenInto(dst, src map[string]any, prefix, sep string) {
+	for k, v := range src {
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			flattenInto(dst, m, prefix+k+sep, sep)
+			continue
+		}
+		dst[prefix+k] = v
+	}
+}
+
+// Unflatten is the inverse of Flatten: it splits each key of the given map by
+// the given separator, and returns a tree of nested maps holding each value at
+// the resulting path. A value that is itself a map[string]any is merged with
+// the values of longer keys below it. It returns an error wrapping
+// ErrPathConflict, naming the keys, if a key holding any other value is also
+// the prefix of another key.
+func Unflatten(m map[string]any, sep string) (map[string]any, error) {
+	ret := make(map[string]any)
+	// shorter keys first, so that a conflict is always found at the longer
+	// key, and otherwise sorted so that the same conflict is always reported
+	depth := func(k string) int { return strings.Count(k, sep) }
+	for _, k := range SortedStableBy(SortedKeys(m), depth) {
+		parts := strings.Split(k, sep)
+		node := ret
+		for i, p := range parts[:len(parts)-1] {
+			child, ok := node[p]
+			if !ok {
+				child = make(map[string]any)
+				node[p] = child
+			}
+			next, ok := child.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q holds a value and is a parent of %q",
+					ErrPathConflict, strings.Join(parts[:i+1], sep), k)
+			}
+			node = next
+		}
+		node[parts[len(parts)-1]] = cloneTree(m[k])
+	}
+	return ret, nil
+}
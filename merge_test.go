@@ -0,0 +1,157 @@
+package fun
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	a := map[string]int{"x": 1, "y": 2}
+	b := map[string]int{"y": 10, "z": 3}
+	c := map[string]int{"y": 100}
+	got := Merge(func(_ string, a, b int) int { return a + b }, a, b, c)
+	if want := map[string]int{"x": 1, "y": 112, "z": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge(sum) = %v, want %v", got, want)
+	}
+	got = Merge(nil, a, b)
+	if want := map[string]int{"x": 1, "y": 10, "z": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge(nil) = %v, want %v", got, want)
+	}
+	// the resolver sees values in argument order
+	keepFirst := func(_ string, a, _ int) int { return a }
+	if got := Merge(keepFirst, c, a)["y"]; got != 100 {
+		t.Errorf("Merge(keep first)[y] = %v, want 100", got)
+	}
+	if got := Merge[map[string]int](nil); got == nil || len(got) != 0 {
+		t.Errorf("Merge() of no maps = %#v, want empty map", got)
+	}
+}
+
+func TestMergeGroups(t *testing.T) {
+	a := map[string][]int{"x": {1, 2}, "e": {}}
+	b := map[string][]int{"x": {3}, "y": {4}}
+	got := MergeGroups(a, b)
+	if want := map[string][]int{"x": {1, 2, 3}, "y": {4}, "e": {}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeGroups() = %v, want %v", got, want)
+	}
+	got["x"][0] = 100
+	if a["x"][0] != 1 {
+		t.Errorf("MergeGroups() result shares storage with its input")
+	}
+}
+
+func decodeTree(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var ret map[string]any
+	if err := json.Unmarshal([]byte(s), &ret); err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestDeepMerge(t *testing.T) {
+	base := `{
+		"name": "svc",
+		"db": {"host": "localhost", "port": 5432, "opts": {"ssl": false}},
+		"tags": ["a", "b"],
+		"users": [{"id": 1, "role": "admin"}, {"id": 2, "role": "dev"}]
+	}`
+	override := `{
+		"db": {"host": "db.internal", "opts": {"timeout": 5}},
+		"tags": ["b", "c"],
+		"users": [{"id": 2, "role": "ops"}, {"id": 3, "role": "dev"}]
+	}`
+	byID := UnionListsBy(func(e any) any { return e.(map[string]any)["id"] })
+	tests := []struct {
+		name  string
+		lists ListStrategy
+		want  string
+	}{
+		{"replace", nil, `{
+			"name": "svc",
+			"db": {"host": "db.internal", "port": 5432, "opts": {"ssl": false, "timeout": 5}},
+			"tags": ["b", "c"],
+			"users": [{"id": 2, "role": "ops"}, {"id": 3, "role": "dev"}]
+		}`},
+		{"append", AppendLists, `{
+			"name": "svc",
+			"db": {"host": "db.internal", "port": 5432, "opts": {"ssl": false, "timeout": 5}},
+			"tags": ["a", "b", "b", "c"],
+			"users": [{"id": 1, "role": "admin"}, {"id": 2, "role": "dev"},
+				{"id": 2, "role": "ops"}, {"id": 3, "role": "dev"}]
+		}`},
+		{"union by path", func(path string, a, b []any) []any {
+			if path == "users" {
+				return byID(path, a, b)
+			}
+			return UnionListsBy(func(e any) any { return e })(path, a, b)
+		}, `{
+			"name": "svc",
+			"db": {"host": "db.internal", "port": 5432, "opts": {"ssl": false, "timeout": 5}},
+			"tags": ["a", "b", "c"],
+			"users": [{"id": 1, "role": "admin"}, {"id": 2, "role": "ops"}, {"id": 3, "role": "dev"}]
+		}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := decodeTree(t, base), decodeTree(t, override)
+			got := DeepMerge(tt.lists, a, b)
+			if want := decodeTree(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("DeepMerge() = %v, want %v", got, want)
+			}
+			// the inputs are left untouched
+			if !reflect.DeepEqual(a, decodeTree(t, base)) || !reflect.DeepEqual(b, decodeTree(t, override)) {
+				t.Errorf("DeepMerge() modified its input")
+			}
+			got["db"].(map[string]any)["opts"].(map[string]any)["ssl"] = true
+			if a["db"].(map[string]any)["opts"].(map[string]any)["ssl"] != false {
+				t.Errorf("DeepMerge() result shares a map with its input")
+			}
+		})
+	}
+	// a scalar replaces a map, and a map replaces a scalar
+	got := DeepMerge(nil, decodeTree(t, `{"a": {"b": 1}, "c": 1}`), decodeTree(t, `{"a": 2, "c": {"d": 3}}`))
+	if want := decodeTree(t, `{"a": 2, "c": {"d": 3}}`); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeepMerge() = %v, want %v", got, want)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tree := decodeTree(t, `{"a": {"b": 1, "c": {"d": "x"}}, "e": [1, 2], "f": {}, "g": null}`)
+	flat := Flatten(tree, ".")
+	want := map[string]any{
+		"a.b": 1.0, "a.c.d": "x", "e": []any{1.0, 2.0}, "f": map[string]any{}, "g": nil,
+	}
+	if !reflect.DeepEqual(flat, want) {
+		t.Errorf("Flatten() = %v, want %v", flat, want)
+	}
+	back, err := Unflatten(flat, ".")
+	if err != nil || !reflect.DeepEqual(back, tree) {
+		t.Errorf("Unflatten(Flatten()) = %v, %v, want %v", back, err, tree)
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	got, err := Unflatten(map[string]any{"a/b": 1, "a/c": 2, "d": 3}, "/")
+	if want := map[string]any{"a": map[string]any{"b": 1, "c": 2}, "d": 3}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Unflatten() = %v, %v, want %v", got, err, want)
+	}
+	_, err = Unflatten(map[string]any{"a": 1, "a.b.c": 2, "a.d": 3}, ".")
+	if !errors.Is(err, ErrPathConflict) {
+		t.Fatalf("Unflatten(conflict) error = %v, want ErrPathConflict", err)
+	}
+	if want := `fun: conflicting keys: "a" holds a value and is a parent of "a.d"`; err.Error() != want {
+		t.Errorf("Unflatten(conflict) error = %q, want %q", err, want)
+	}
+	// a map value is merged with the keys below it, without being modified
+	sub := map[string]any{"b": 1}
+	got, err = Unflatten(map[string]any{"a": sub, "a.c": 2}, ".")
+	if want := map[string]any{"a": map[string]any{"b": 1, "c": 2}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Unflatten(map value) = %v, %v, want %v", got, err, want)
+	}
+	if len(sub) != 1 {
+		t.Errorf("Unflatten() modified a map value of its input: %v", sub)
+	}
+}
@@ -0,0 +1,249 @@
+package fun
+
+// Multimap maps each key to a list of values, keeping duplicates and the order
+// in which values were added. It has the same shape as the maps built by
+// GroupBy and AppendToGroup, so those can be converted to a Multimap without
+// copying. Keys whose last value is removed are deleted.
+// A nil Multimap can be read from, but must be created using make or
+// AsMultimap before values are added.
+type Multimap[K, V comparable] map[K][]V
+
+// AsMultimap returns the given map of slices as a Multimap. The returned
+// Multimap shares storage with the given map.
+func AsMultimap[M ~map[K][]V, K, V comparable](m M) Multimap[K, V] {
+	return Multimap[K, V](m)
+}
+
+// Put adds the given value to the values of the given key
+func (m Multimap[K, V]) Put(k K, v V) {
+	AppendToGroup(m, k, v)
+}
+
+// PutAll adds the given values to the values of the given key
+func (m Multimap[K, V]) PutAll(k K, vs ...V) {
+	if len(vs) == 0 {
+		return
+	}
+	m[k] = append(m[k], vs...)
+}
+
+// Remove removes the first occurrence of the given value from the values of
+// the given key, returning true if it was present
+func (m Multimap[K, V]) Remove(k K, v V) bool {
+	vs := m[k]
+	for i, e := range vs {
+		if e == v {
+			if len(vs) == 1 {
+				delete(m, k)
+				return true
+			}
+			// build a new slice rather than shifting the values in place, which
+			// would rearrange the views returned by earlier calls to Get
+			rest := make([]V, 0, len(vs)-1)
+			rest = append(rest, vs[:i]...)
+			m[k] = append(rest, vs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAll removes the given key, returning the values it had, or an empty
+// slice if it had none
+func (m Multimap[K, V]) RemoveAll(k K) []V {
+	vs := m.Get(k)
+	delete(m, k)
+	return vs
+}
+
+// Get returns the values of the given key, or an empty slice if it has none.
+// The result is a view of the values held by the multimap, with its capacity
+// limited to its length so that appending to it cannot change the multimap.
+// Later calls to Put, PutAll and Remove do not change a returned view.
+func (m Multimap[K, V]) Get(k K) []V {
+	vs := m[k]
+	return view(vs, 0, len(vs))
+}
+
+// ContainsKey returns true if the given key has at least one value
+func (m Multimap[K, V]) ContainsKey(k K) bool {
+	return len(m[k]) > 0
+}
+
+// ContainsEntry returns true if the given value is among the values of the
+// given key
+func (m Multimap[K, V]) ContainsEntry(k K, v V) bool {
+	for _, e := range m[k] {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of the multimap, in no particular order
+func (m Multimap[K, V]) Keys() []K {
+	ret := make([]K, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+// Entries returns every (key, value) pair of the multimap. Keys are in no
+// particular order, while the values of each key keep their order.
+func (m Multimap[K, V]) Entries() []*Pair[K, V] {
+	ret := make([]*Pair[K, V], 0, m.Len())
+	for k, vs := range m {
+		for _, v := range vs {
+			ret = append(ret, &Pair[K, V]{k, v})
+		}
+	}
+	return ret
+}
+
+// Len returns the total number of values across all keys
+func (m Multimap[K, V]) Len() int {
+	n := 0
+	for _, vs := range m {
+		n += len(vs)
+	}
+	return n
+}
+
+// Inverse returns a new multimap mapping each value to the keys it appeared
+// under. A key is repeated if it had the same value more than once.
+func (m Multimap[K, V]) Inverse() Multimap[V, K] {
+	ret := make(Multimap[V, K])
+	for k, vs := range m {
+		for _, v := range vs {
+			ret.Put(v, k)
+		}
+	}
+	return ret
+}
+
+// ToSetMultimap returns a new set-valued multimap with the same entries, which
+// drops duplicate values within each key
+func (m Multimap[K, V]) ToSetMultimap() SetMultimap[K, V] {
+	ret := make(SetMultimap[K, V], len(m))
+	for k, vs := range m {
+		ret.PutAll(k, vs...)
+	}
+	return ret
+}
+
+// SetMultimap maps each key to a set of distinct values. Keys whose last
+// value is removed are deleted.
+// A nil SetMultimap can be read from, but must be created using make before
+// values are added.
+type SetMultimap[K, V comparable] map[K]Set[V]
+
+// Put adds the given value to the values of the given key, returning true if
+// it was not already present
+func (m SetMultimap[K, V]) Put(k K, v V) bool {
+	vs, ok := m[k]
+	if !ok {
+		vs = make(Set[V])
+		m[k] = vs
+	}
+	if vs.Contains(v) {
+		return false
+	}
+	vs.Add(v)
+	return true
+}
+
+// PutAll adds the given values to the values of the given key
+func (m SetMultimap[K, V]) PutAll(k K, vs ...V) {
+	for _, v := range vs {
+		m.Put(k, v)
+	}
+}
+
+// Remove removes the given value from the values of the given key, returning
+// true if it was present
+func (m SetMultimap[K, V]) Remove(k K, v V) bool {
+	vs := m[k]
+	if !vs.Contains(v) {
+		return false
+	}
+	vs.Remove(v)
+	if vs.Len() == 0 {
+		delete(m, k)
+	}
+	return true
+}
+
+// RemoveAll removes the given key, returning the values it had, or an empty
+// set if it had none
+func (m SetMultimap[K, V]) RemoveAll(k K) Set[V] {
+	vs := m.Get(k)
+	delete(m, k)
+	return vs
+}
+
+// Get returns the values of the given key, or an empty set if it has none.
+// For a key with values, the set is the one held by the multimap, so changing
+// it changes the multimap. For a key without values, the empty set is new and
+// is not added to the multimap, so adding to it does not change the multimap.
+func (m SetMultimap[K, V]) Get(k K) Set[V] {
+	vs, ok := m[k]
+	if !ok {
+		return make(Set[V])
+	}
+	return vs
+}
+
+// ContainsKey returns true if the given key has at least one value
+func (m SetMultimap[K, V]) ContainsKey(k K) bool {
+	return m[k].Len() > 0
+}
+
+// ContainsEntry returns true if the given value is among the values of the
+// given key
+func (m SetMultimap[K, V]) ContainsEntry(k K, v V) bool {
+	return m[k].Contains(v)
+}
+
+// Keys returns the keys of the multimap, in no particular order
+func (m SetMultimap[K, V]) Keys() []K {
+	ret := make([]K, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+// Entries returns every (key, value) pair of the multimap, in no particular
+// order
+func (m SetMultimap[K, V]) Entries() []*Pair[K, V] {
+	ret := make([]*Pair[K, V], 0, m.Len())
+	for k, vs := range m {
+		for v := range vs {
+			ret = append(ret, &Pair[K, V]{k, v})
+		}
+	}
+	return ret
+}
+
+// Len returns the total number of values across all keys
+func (m SetMultimap[K, V]) Len() int {
+	n := 0
+	for _, vs := range m {
+		n += vs.Len()
+	}
+	return n
+}
+
+// Inverse returns a new multimap mapping each value to the keys it appeared
+// under
+func (m SetMultimap[K, V]) Inverse() SetMultimap[V, K] {
+	ret := make(SetMultimap[V, K])
+	for k, vs := range m {
+		for v := range vs {
+			ret.Put(v, k)
+		}
+	}
+	return ret
+}
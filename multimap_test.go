@@ -0,0 +1,140 @@
+package fun
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func sortedEntries[K, V comparable](ps []*Pair[K, V], less func(a, b *Pair[K, V]) bool) []*Pair[K, V] {
+	sort.SliceStable(ps, func(i, j int) bool { return less(ps[i], ps[j]) })
+	return ps
+}
+
+func TestMultimap(t *testing.T) {
+	m := make(Multimap[string, int])
+	m.Put("a", 1)
+	m.PutAll("a", 2, 1)
+	m.Put("b", 3)
+	m.PutAll("c")
+
+	if got := m.Get("a"); !reflect.DeepEqual(got, []int{1, 2, 1}) {
+		t.Errorf("Get(a) = %v, want [1 2 1]", got)
+	}
+	if m.Len() != 4 || m.ContainsKey("c") {
+		t.Errorf("Len() = %d, ContainsKey(c) = %v, want 4, false", m.Len(), m.ContainsKey("c"))
+	}
+	if !m.ContainsEntry("a", 2) || m.ContainsEntry("b", 2) {
+		t.Errorf("ContainsEntry() mismatch for %v", m)
+	}
+
+	if !m.Remove("a", 1) || !reflect.DeepEqual(m.Get("a"), []int{2, 1}) {
+		t.Errorf("Remove(a, 1) left %v, want [2 1]", m.Get("a"))
+	}
+	if m.Remove("a", 7) {
+		t.Errorf("Remove(a, 7) reported a missing value as removed")
+	}
+	if !m.Remove("b", 3) || m.ContainsKey("b") {
+		t.Errorf("Remove(b, 3) did not delete the emptied key")
+	}
+	if got := m.RemoveAll("a"); !reflect.DeepEqual(got, []int{2, 1}) || len(m) != 0 {
+		t.Errorf("RemoveAll(a) = %v leaving %v", got, m)
+	}
+
+	// removing a value does not rearrange a view returned earlier by Get
+	m.PutAll("d", 1, 2, 3)
+	view := m.Get("d")
+	if !m.Remove("d", 1) || !reflect.DeepEqual(m.Get("d"), []int{2, 3}) {
+		t.Errorf("Remove(d, 1) left %v, want [2 3]", m.Get("d"))
+	}
+	if !reflect.DeepEqual(view, []int{1, 2, 3}) {
+		t.Errorf("Remove(d, 1) changed an earlier Get(d) to %v, want [1 2 3]", view)
+	}
+}
+
+func TestMultimapFromGroupBy(t *testing.T) {
+	groups := GroupBy([]string{"a", "bb", "c", "dd", "a"}, func(s string) (int, string) {
+		return len(s), s
+	})
+	m := AsMultimap(groups)
+	m.Put(3, "eee")
+	if !reflect.DeepEqual(groups[3], []string{"eee"}) {
+		t.Errorf("AsMultimap() does not share storage with the GroupBy result")
+	}
+
+	keys := m.Keys()
+	sort.Ints(keys)
+	if !reflect.DeepEqual(keys, []int{1, 2, 3}) {
+		t.Errorf("Keys() = %v, want [1 2 3]", keys)
+	}
+
+	entries := sortedEntries(m.Entries(), func(a, b *Pair[int, string]) bool {
+		return a.Fst < b.Fst
+	})
+	want := []*Pair[int, string]{{1, "a"}, {1, "c"}, {1, "a"}, {2, "bb"}, {2, "dd"}, {3, "eee"}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Entries() = %v, want %v", entries, want)
+	}
+
+	inv := m.Inverse()
+	if !reflect.DeepEqual(inv.Get("a"), []int{1, 1}) || !reflect.DeepEqual(inv.Get("dd"), []int{2}) {
+		t.Errorf("Inverse() = %v", inv)
+	}
+
+	set := m.ToSetMultimap()
+	if set.Len() != 5 || !set.Get(1).Equal(NewSet("a", "c")) {
+		t.Errorf("ToSetMultimap() = %v", set)
+	}
+}
+
+func TestSetMultimap(t *testing.T) {
+	m := make(SetMultimap[string, int])
+	if !m.Put("a", 1) || m.Put("a", 1) {
+		t.Errorf("Put() did not report duplicates correctly")
+	}
+	m.PutAll("a", 2, 3, 2)
+	m.Put("b", 1)
+
+	if m.Len() != 4 || !m.Get("a").Equal(NewSet(1, 2, 3)) {
+		t.Errorf("Len() = %d, Get(a) = %v", m.Len(), m.Get("a"))
+	}
+	if !m.ContainsEntry("b", 1) || m.ContainsEntry("b", 2) || m.ContainsEntry("z", 1) {
+		t.Errorf("ContainsEntry() mismatch for %v", m)
+	}
+	if !m.ContainsKey("a") || m.ContainsKey("z") {
+		t.Errorf("ContainsKey() mismatch for %v", m)
+	}
+
+	inv := m.Inverse()
+	if !inv.Get(1).Equal(NewSet("a", "b")) || !inv.Get(3).Equal(NewSet("a")) {
+		t.Errorf("Inverse() = %v", inv)
+	}
+
+	entries := sortedEntries(m.Entries(), func(a, b *Pair[string, int]) bool {
+		return a.Fst < b.Fst || (a.Fst == b.Fst && a.Snd < b.Snd)
+	})
+	want := []*Pair[string, int]{{"a", 1}, {"a", 2}, {"a", 3}, {"b", 1}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Entries() = %v, want %v", entries, want)
+	}
+
+	if !m.Remove("b", 1) || m.ContainsKey("b") || m.Remove("b", 1) {
+		t.Errorf("Remove(b, 1) did not delete the emptied key")
+	}
+	if got := m.RemoveAll("a"); !got.Equal(NewSet(1, 2, 3)) || len(m) != 0 {
+		t.Errorf("RemoveAll(a) = %v leaving %v", got, m)
+	}
+
+	// the set of a key with values is shared, the empty set of a missing key
+	// is not
+	m.Put("c", 1)
+	m.Get("c").Add(2)
+	m.Get("z").Add(1)
+	if !m.Get("c").Equal(NewSet(1, 2)) || m.ContainsKey("z") {
+		t.Errorf("changing the results of Get() left %v, want only c: {1 2}", m)
+	}
+	keys := SetMultimap[int, int]{1: NewSet(1)}.Keys()
+	if !reflect.DeepEqual(keys, []int{1}) {
+		t.Errorf("Keys() = %v, want [1]", keys)
+	}
+}
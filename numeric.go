@@ -0,0 +1,176 @@
+package fun
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Signed is a constraint permitting any signed integer type
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint permitting any unsigned integer type
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint permitting any integer type
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint permitting any floating-point type
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint permitting any integer or floating-point type
+type Number interface {
+	Integer | Float
+}
+
+// ErrOverflow is returned by SumChecked when the sum does not fit in the
+// element type
+var ErrOverflow = errors.New("fun: integer overflow")
+
+// Sum returns the sum of the elements of the given slice, or zero for an
+// empty slice. Floating-point elements are summed with Kahan-Babuska
+// compensation to limit rounding errors. Integer sums wrap around on
+// overflow; use SumChecked to detect it.
+func Sum[T Number](s []T) T {
+	if isFloat[T]() {
+		return compensatedSum(s)
+	}
+	var sum T
+	for _, e := range s {
+		sum += e
+	}
+	return sum
+}
+
+// SumBy returns the sum of the numbers returned by the given function applied
+// to each element of the given slice, like Sum
+func SumBy[T any, N Number](s []T, fn func(T) N) N {
+	return Sum(Map(s, fn))
+}
+
+// SumChecked returns the sum of the elements of the given slice, or
+// ErrOverflow if the sum, or any partial sum, does not fit in the element type
+func SumChecked[T Integer](s []T) (T, error) {
+	var sum T
+	for _, e := range s {
+		next := sum + e
+		if (e > 0 && next < sum) || (e < 0 && next > sum) {
+			return 0, ErrOverflow
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// Product returns the product of the elements of the given slice, or one for
+// an empty slice
+func Product[T Number](s []T) T {
+	var product T = 1
+	for _, e := range s {
+		product *= e
+	}
+	return product
+}
+
+// Mean returns the arithmetic mean of the elements of the given slice, or an
+// empty Option for an empty slice
+func Mean[T Number](s []T) Option[float64] {
+	if len(s) == 0 {
+		return None[float64]()
+	}
+	floats := Map(s, func(e T) float64 { return float64(e) })
+	return Some(compensatedSum(floats) / float64(len(s)))
+}
+
+// MeanBy returns the arithmetic mean of the numbers returned by the given
+// function applied to each element of the given slice, or an empty Option for
+// an empty slice
+func MeanBy[T any, N Number](s []T, fn func(T) N) Option[float64] {
+	return Mean(Map(s, fn))
+}
+
+// Max returns the largest element of the given slice, or an empty Option for
+// an empty slice. NaN values are ordered before all other floats, as in
+// cmp.Compare.
+func Max[T cmp.Ordered](s []T) Option[T] {
+	return MaxBy(s, func(e T) T { return e })
+}
+
+// Min returns the smallest element of the given slice, or an empty Option for
+// an empty slice. NaN values are ordered before all other floats, as in
+// cmp.Compare.
+func Min[T cmp.Ordered](s []T) Option[T] {
+	return MinBy(s, func(e T) T { return e })
+}
+
+// MinMax returns the smallest and largest elements of the given slice in a
+// single pass, or an empty Option for an empty slice
+func MinMax[T cmp.Ordered](s []T) Option[Pair[T, T]] {
+	return MinMaxBy(s, func(e T) T { return e })
+}
+
+// MinMaxBy returns the first elements yielding the smallest and largest values
+// of the given selector function, or an empty Option for an empty slice. The
+// selector is called once per element.
+func MinMaxBy[T any, K cmp.Ordered](s []T, fn func(T) K) Option[Pair[T, T]] {
+	if len(s) == 0 {
+		return None[Pair[T, T]]()
+	}
+	lo, hi := s[0], s[0]
+	loKey := fn(s[0])
+	hiKey := loKey
+	for _, e := range s[1:] {
+		k := fn(e)
+		if cmp.Less(k, loKey) {
+			lo, loKey = e, k
+		}
+		if cmp.Less(hiKey, k) {
+			hi, hiKey = e, k
+		}
+	}
+	return Some(Pair[T, T]{lo, hi})
+}
+
+// isFloat reports whether T is a floating-point type, by checking whether
+// dividing one by two truncates to zero
+func isFloat[T Number]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+// compensatedSum adds the given numbers using the Kahan-Babuska (Neumaier)
+// algorithm, which keeps a running compensation for lost low-order bits.
+// An infinite or NaN sum is returned as is.
+func compensatedSum[T Number](s []T) T {
+	var sum, c T
+	for _, e := range s {
+		t := sum + e
+		if abs(sum) >= abs(e) {
+			c += (sum - t) + e
+		} else {
+			c += (e - t) + sum
+		}
+		sum = t
+	}
+	// once the sum is infinite the compensation is Inf-Inf, which is NaN;
+	// x-x is non-zero only for infinities and NaN
+	if sum-sum != 0 {
+		return sum
+	}
+	return sum + c
+}
+
+func abs[T Number](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,166 @@
+package fun
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+type celsius float64
+
+func TestSum(t *testing.T) {
+	if got := Sum([]int{1, 2, 3, 4, 5}); got != 15 {
+		t.Errorf("Sum() = %v, want 15", got)
+	}
+	if got := Sum([]int{}); got != 0 {
+		t.Errorf("Sum(empty) = %v, want 0", got)
+	}
+	if got := Sum([]uint8{200, 100}); got != 44 {
+		t.Errorf("Sum() = %v, want wrapped 44", got)
+	}
+	if got := Sum([]celsius{1.5, 2.5}); got != 4 {
+		t.Errorf("Sum() = %v, want 4", got)
+	}
+
+	// naive summation loses the small terms entirely
+	floats := []float64{1e16, 1, 1, 1, 1, -1e16}
+	if got := Sum(floats); got != 4 {
+		t.Errorf("Sum() = %v, want 4", got)
+	}
+	tenths := make([]float64, 1000)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+	if got := Sum(tenths); got != 100 {
+		t.Errorf("Sum() = %v, want 100", got)
+	}
+	if got := Sum([]float32{1e8, 1, -1e8}); got != 1 {
+		t.Errorf("Sum() = %v, want 1", got)
+	}
+
+	// infinities are kept, not turned into NaN by the compensation
+	inf := math.Inf(1)
+	for _, tt := range []struct {
+		s    []float64
+		want float64
+	}{
+		{[]float64{inf}, inf},
+		{[]float64{1, inf}, inf},
+		{[]float64{inf, 1, 2}, inf},
+		{[]float64{-inf, 1}, -inf},
+		{[]float64{math.MaxFloat64, math.MaxFloat64}, inf},
+	} {
+		if got := Sum(tt.s); got != tt.want {
+			t.Errorf("Sum(%v) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+	if got := Sum([]float64{inf, -inf}); !math.IsNaN(got) {
+		t.Errorf("Sum(+Inf, -Inf) = %v, want NaN", got)
+	}
+
+	type item struct{ qty int }
+	if got := SumBy([]item{{2}, {3}}, func(i item) int { return i.qty }); got != 5 {
+		t.Errorf("SumBy() = %v, want 5", got)
+	}
+}
+
+func TestSumChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		sum     func() (int64, error)
+		want    int64
+		wantErr error
+	}{
+		{"no overflow",
+			func() (int64, error) { return SumChecked([]int64{1, 2, 3}) },
+			6, nil,
+		},
+		{"positive overflow",
+			func() (int64, error) { return SumChecked([]int64{math.MaxInt64, 1}) },
+			0, ErrOverflow,
+		},
+		{"negative overflow",
+			func() (int64, error) { return SumChecked([]int64{math.MinInt64, -1}) },
+			0, ErrOverflow,
+		},
+		{"cancelling extremes",
+			func() (int64, error) { return SumChecked([]int64{math.MaxInt64, math.MinInt64, 1}) },
+			0, nil,
+		},
+		{"unsigned overflow",
+			func() (int64, error) {
+				sum, err := SumChecked([]uint8{200, 100})
+				return int64(sum), err
+			},
+			0, ErrOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sum()
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("SumChecked() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProduct(t *testing.T) {
+	if got := Product([]int{1, 2, 3, 4}); got != 24 {
+		t.Errorf("Product() = %v, want 24", got)
+	}
+	if got := Product([]float64{}); got != 1 {
+		t.Errorf("Product(empty) = %v, want 1", got)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if got := Mean([]int{1, 2, 3, 4}); got != Some(2.5) {
+		t.Errorf("Mean() = %v, want Some(2.5)", got)
+	}
+	if got := Mean([]int{}); got.IsSome() {
+		t.Errorf("Mean(empty) = %v, want None", got)
+	}
+	if got := MeanBy([]string{"a", "bbb"}, func(s string) int { return len(s) }); got != Some(2.0) {
+		t.Errorf("MeanBy() = %v, want Some(2)", got)
+	}
+	if got := Mean([]float64{math.Inf(1)}); got != Some(math.Inf(1)) {
+		t.Errorf("Mean(+Inf) = %v, want Some(+Inf)", got)
+	}
+	if got := Mean([]float64{1, math.Inf(-1)}); got != Some(math.Inf(-1)) {
+		t.Errorf("Mean(1, -Inf) = %v, want Some(-Inf)", got)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	s := []int{4, 2, 8, 2, 9, 1, 9}
+	if got := Min(s); got != Some(1) {
+		t.Errorf("Min() = %v, want Some(1)", got)
+	}
+	if got := Max(s); got != Some(9) {
+		t.Errorf("Max() = %v, want Some(9)", got)
+	}
+	if got := MinMax(s); got != Some(Pair[int, int]{1, 9}) {
+		t.Errorf("MinMax() = %v, want Some((1, 9))", got)
+	}
+	if got := MinMax([]int{}); got.IsSome() {
+		t.Errorf("MinMax(empty) = %v, want None", got)
+	}
+	if got := Min([]string{}); got.IsSome() {
+		t.Errorf("Min(empty) = %v, want None", got)
+	}
+
+	words := []string{"bb", "a", "ccc", "d", "eee"}
+	got := MinMaxBy(words, func(w string) int { return len(w) })
+	if got != Some(Pair[string, string]{"a", "ccc"}) {
+		t.Errorf("MinMaxBy() = %v, want Some((a, ccc))", got)
+	}
+
+	withNaN := []float64{2, math.NaN(), 1}
+	if v := Min(withNaN).MustGet(); !math.IsNaN(v) {
+		t.Errorf("Min() = %v, want NaN", v)
+	}
+	if v := Max(withNaN).MustGet(); v != 2 {
+		t.Errorf("Max() = %v, want 2", v)
+	}
+}
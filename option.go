@@ -0,0 +1,214 @@
+package fun
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+)
+
+// Option represents a value that may or may not be present.
+// The zero value is an empty Option.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Option holding the given value
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, ok: true}
+}
+
+// None returns an empty Option
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// Get returns the value held by the Option and whether it is present
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// IsSome returns true if the Option holds a value
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone returns true if the Option is empty
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// MustGet returns the value held by the Option, and panics if it is empty
+func (o Option[T]) MustGet() T {
+	if !o.ok {
+		panic("fun: MustGet called on empty Option")
+	}
+	return o.value
+}
+
+// OrElse returns the value held by the Option, or the given value if it is
+// empty
+func (o Option[T]) OrElse(v T) T {
+	if o.ok {
+		return o.value
+	}
+	return v
+}
+
+// OrElseGet returns the value held by the Option, or the result of the given
+// function if it is empty. The function is only invoked for an empty Option.
+func (o Option[T]) OrElseGet(fn func() T) T {
+	if o.ok {
+		return o.value
+	}
+	return fn()
+}
+
+func (o Option[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// MarshalJSON encodes the held value, or null for an empty Option
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as an empty Option and anything else as a held
+// value
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// OptionMap returns an Option holding the result of applying the given
+// function to the value of the given Option, or an empty Option if it is empty
+func OptionMap[T1, T2 any](o Option[T1], fn func(T1) T2) Option[T2] {
+	if !o.ok {
+		return None[T2]()
+	}
+	return Some(fn(o.value))
+}
+
+// OptionFlatMap returns the Option returned by the given function applied to
+// the value of the given Option, or an empty Option if it is empty
+func OptionFlatMap[T1, T2 any](o Option[T1], fn func(T1) Option[T2]) Option[T2] {
+	if !o.ok {
+		return None[T2]()
+	}
+	return fn(o.value)
+}
+
+// Find returns the first element satisfying the given predicate, or an empty
+// Option if there is none
+func Find[T any](s []T, fn func(T) bool) Option[T] {
+	for _, e := range s {
+		if fn(e) {
+			return Some(e)
+		}
+	}
+	return None[T]()
+}
+
+// FindLast returns the last element satisfying the given predicate, or an
+// empty Option if there is none
+func FindLast[T any](s []T, fn func(T) bool) Option[T] {
+	for i := len(s) - 1; i >= 0; i-- {
+		if fn(s[i]) {
+			return Some(s[i])
+		}
+	}
+	return None[T]()
+}
+
+// First returns the first element of the given slice, or an empty Option if
+// the slice is empty
+func First[T any](s []T) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(s[0])
+}
+
+// Last returns the last element of the given slice, or an empty Option if the
+// slice is empty
+func Last[T any](s []T) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(s[len(s)-1])
+}
+
+// MaxBy returns the first element yielding the largest value of the given
+// selector function, or an empty Option if the slice is empty
+func MaxBy[T any, K cmp.Ordered](s []T, fn func(T) K) Option[T] {
+	return extremeBy(s, fn, func(k, best K) bool { return cmp.Less(best, k) })
+}
+
+// MinBy returns the first element yielding the smallest value of the given
+// selector function, or an empty Option if the slice is empty
+func MinBy[T any, K cmp.Ordered](s []T, fn func(T) K) Option[T] {
+	return extremeBy(s, fn, func(k, best K) bool { return cmp.Less(k, best) })
+}
+
+// extremeBy returns the first element whose key beats the keys of all
+// elements before it, calling the selector once per element
+func extremeBy[T any, K cmp.Ordered](
+	s []T,
+	fn func(T) K,
+	better func(K, K) bool,
+) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	best, bestKey := s[0], fn(s[0])
+	for _, e := range s[1:] {
+		if k := fn(e); better(k, bestKey) {
+			best, bestKey = e, k
+		}
+	}
+	return Some(best)
+}
+
+// ReduceOpt accumulates the values starting with the first element and
+// applying the operation from left to right to the current accumulator value
+// and each element. Unlike Reduce, it returns an empty Option for an empty
+// slice instead of panicking.
+func ReduceOpt[T any](s []T, fn func(T, T) T) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(Reduce(s, fn))
+}
+
+// ReduceIndexedOpt is like ReduceIndexed, but returns an empty Option for an
+// empty slice instead of panicking.
+func ReduceIndexedOpt[T any](s []T, fn func(int, T, T) T) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(ReduceIndexed(s, fn))
+}
+
+// Single returns the only element of the given slice, or an empty Option if
+// the slice is empty or has more than one element
+func Single[T any](s []T) Option[T] {
+	if len(s) != 1 {
+		return None[T]()
+	}
+	return Some(s[0])
+}
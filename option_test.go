@@ -0,0 +1,157 @@
+package fun
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOption(t *testing.T) {
+	some := Some(42)
+	none := None[int]()
+
+	if v, ok := some.Get(); !ok || v != 42 {
+		t.Errorf("Some(42).Get() = %v, %v, want 42, true", v, ok)
+	}
+	if v, ok := none.Get(); ok || v != 0 {
+		t.Errorf("None().Get() = %v, %v, want 0, false", v, ok)
+	}
+	if !some.IsSome() || some.IsNone() || none.IsSome() || !none.IsNone() {
+		t.Errorf("IsSome/IsNone mismatch for %v and %v", some, none)
+	}
+	if got := some.OrElse(7); got != 42 {
+		t.Errorf("Some(42).OrElse(7) = %v, want 42", got)
+	}
+	if got := none.OrElse(7); got != 7 {
+		t.Errorf("None().OrElse(7) = %v, want 7", got)
+	}
+	calls := 0
+	fallback := func() int { calls++; return 7 }
+	if got := some.OrElseGet(fallback); got != 42 || calls != 0 {
+		t.Errorf("Some(42).OrElseGet() = %v after %d calls, want 42 after 0", got, calls)
+	}
+	if got := none.OrElseGet(fallback); got != 7 || calls != 1 {
+		t.Errorf("None().OrElseGet() = %v after %d calls, want 7 after 1", got, calls)
+	}
+	if got := fmt.Sprint(some, " ", none); got != "Some(42) None" {
+		t.Errorf("String() = %q, want %q", got, "Some(42) None")
+	}
+	var zero Option[string]
+	if zero.IsSome() {
+		t.Errorf("zero Option is not empty")
+	}
+}
+
+func TestOptionMustGetPanicsOnNone(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustGet() on None did not panic")
+		}
+	}()
+	None[int]().MustGet()
+}
+
+func TestOptionMapAndFlatMap(t *testing.T) {
+	toString := func(i int) string { return fmt.Sprint(i * 2) }
+	if got := OptionMap(Some(21), toString); got != Some("42") {
+		t.Errorf("OptionMap(Some) = %v, want Some(42)", got)
+	}
+	if got := OptionMap(None[int](), toString); got != None[string]() {
+		t.Errorf("OptionMap(None) = %v, want None", got)
+	}
+	half := func(i int) Option[int] {
+		if i%2 != 0 {
+			return None[int]()
+		}
+		return Some(i / 2)
+	}
+	if got := OptionFlatMap(Some(42), half); got != Some(21) {
+		t.Errorf("OptionFlatMap(Some(42)) = %v, want Some(21)", got)
+	}
+	if got := OptionFlatMap(Some(21), half); got != None[int]() {
+		t.Errorf("OptionFlatMap(Some(21)) = %v, want None", got)
+	}
+	if got := OptionFlatMap(None[int](), half); got != None[int]() {
+		t.Errorf("OptionFlatMap(None) = %v, want None", got)
+	}
+}
+
+func TestOptionJSON(t *testing.T) {
+	type doc struct {
+		A Option[int]    `json:"a"`
+		B Option[string] `json:"b"`
+	}
+	in := doc{Some(1), None[string]()}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"a":1,"b":null}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+	var out doc
+	if err := json.Unmarshal([]byte(`{"a": 1, "b": null}`), &out); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal() = %v, want %v", out, in)
+	}
+	if err := json.Unmarshal([]byte(`{"a": "x"}`), &out); err == nil {
+		t.Errorf("json.Unmarshal() of mistyped value did not fail")
+	}
+}
+
+func TestOptionTerminals(t *testing.T) {
+	s := []string{"bb", "a", "ccc", "dd", "eee"}
+	var empty []string
+	isLong := func(w string) bool { return len(w) > 1 }
+	tests := []struct {
+		name string
+		got  Option[string]
+		want Option[string]
+	}{
+		{"First", First(s), Some("bb")},
+		{"First empty", First(empty), None[string]()},
+		{"Last", Last(s), Some("eee")},
+		{"Last empty", Last(empty), None[string]()},
+		{"Find", Find(s, isLong), Some("bb")},
+		{"Find no match", Find(s, func(w string) bool { return w == "z" }), None[string]()},
+		{"FindLast", FindLast(s, func(w string) bool { return len(w) == 2 }), Some("dd")},
+		{"FindLast empty", FindLast(empty, isLong), None[string]()},
+		{"MinBy", MinBy(s, func(w string) int { return len(w) }), Some("a")},
+		{"MaxBy first of ties", MaxBy(s, func(w string) int { return len(w) }), Some("ccc")},
+		{"MaxBy empty", MaxBy(empty, func(w string) int { return len(w) }), None[string]()},
+		{"Single", Single([]string{"x"}), Some("x")},
+		{"Single many", Single(s), None[string]()},
+		{"Single empty", Single(empty), None[string]()},
+		{"ReduceOpt", ReduceOpt(s, func(a, b string) string { return a + b }), Some("bbacccddeee")},
+		{"ReduceOpt empty", ReduceOpt(empty, func(a, b string) string { return a + b }), None[string]()},
+		{"ReduceIndexedOpt",
+			ReduceIndexedOpt(s[:3], func(i int, a, b string) string {
+				return a + strings.Repeat(b, i)
+			}),
+			Some("bbacccccc"),
+		},
+		{"ReduceIndexedOpt empty",
+			ReduceIndexedOpt(empty, func(i int, a, b string) string { return a + b }),
+			None[string](),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinByCallsSelectorOncePerElement(t *testing.T) {
+	calls := 0
+	MinBy([]int{3, 1, 2}, func(i int) int { calls++; return i })
+	if calls != 3 {
+		t.Errorf("MinBy() called selector %d times, want 3", calls)
+	}
+}
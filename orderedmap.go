@@ -0,0 +1,269 @@
+package fun
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// OrderedMap is a map that remembers the order in which keys were first
+// inserted. Updating the value of an existing key keeps its position, while
+// deleting and re-inserting a key moves it to the end.
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	entries    map[K]*orderedEntry[K, V]
+	head, tail *orderedEntry[K, V]
+}
+
+// orderedEntry is a node in the doubly linked list that records insertion order
+type orderedEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *orderedEntry[K, V]
+}
+
+// NewOrderedMap returns an empty ordered map
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// OrderedMapOf returns an ordered map with the entries of the given map,
+// inserted in ascending key order
+func OrderedMapOf[M ~map[K]V, K cmp.Ordered, V any](m M) *OrderedMap[K, V] {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	ret := NewOrderedMap[K, V]()
+	for _, k := range keys {
+		ret.Set(k, m[k])
+	}
+	return ret
+}
+
+// Get returns the value for the given key and whether it was present
+func (m *OrderedMap[K, V]) Get(k K) (V, bool) {
+	if e, ok := m.entries[k]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the given key is present
+func (m *OrderedMap[K, V]) Has(k K) bool {
+	_, ok := m.entries[k]
+	return ok
+}
+
+// Set sets the value for the given key. A new key is added at the end, while
+// an existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(k K, v V) {
+	if e, ok := m.entries[k]; ok {
+		e.value = v
+		return
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedEntry[K, V])
+	}
+	e := &orderedEntry[K, V]{key: k, value: v, prev: m.tail}
+	if m.tail == nil {
+		m.head = e
+	} else {
+		m.tail.next = e
+	}
+	m.tail = e
+	m.entries[k] = e
+}
+
+// Delete removes the given key, returning true if it was present
+func (m *OrderedMap[K, V]) Delete(k K) bool {
+	e, ok := m.entries[k]
+	if !ok {
+		return false
+	}
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	delete(m.entries, k)
+	return true
+}
+
+// Len returns the number of entries in the map
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// All returns a sequence over the key, value pairs in insertion order
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = e.next {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the keys of the map in insertion order
+func (m *OrderedMap[K, V]) Keys() []K {
+	ret := make([]K, 0, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		ret = append(ret, e.key)
+	}
+	return ret
+}
+
+// Values returns the values of the map in insertion order of their keys
+func (m *OrderedMap[K, V]) Values() []V {
+	ret := make([]V, 0, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		ret = append(ret, e.value)
+	}
+	return ret
+}
+
+// Items returns the (key, value) pairs of the map in insertion order
+func (m *OrderedMap[K, V]) Items() []*Pair[K, V] {
+	ret := make([]*Pair[K, V], 0, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		ret = append(ret, &Pair[K, V]{e.key, e.value})
+	}
+	return ret
+}
+
+// ToMap returns the entries as a regular, unordered map
+func (m *OrderedMap[K, V]) ToMap() map[K]V {
+	ret := make(map[K]V, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		ret[e.key] = e.value
+	}
+	return ret
+}
+
+func (m *OrderedMap[K, V]) String() string {
+	parts := make([]string, 0, m.Len())
+	for e := m.head; e != nil; e = e.next {
+		parts = append(parts, fmt.Sprintf("%v:%v", e.key, e.value))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// MarshalJSON encodes the map as a JSON object with its keys in insertion
+// order. Keys must encode as JSON strings or numbers.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for e := m.head; e != nil; e = e.next {
+		if e != m.head {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(e.key)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(k) > 0 && k[0] == '"':
+		case json.Valid(k) && (k[0] == '-' || (k[0] >= '0' && k[0] <= '9')):
+			k, _ = json.Marshal(string(k))
+		default:
+			return nil, fmt.Errorf("fun: unsupported OrderedMap key %s", k)
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, replacing its contents and
+// preserving the order of the keys in the input. Like encoding/json, null
+// leaves the map unchanged.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("fun: cannot unmarshal %v into OrderedMap", tok)
+	}
+	ret := OrderedMap[K, V]{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var k K
+		raw := tok.(string)
+		// re-encode the key as a JSON string, which cannot fail
+		quoted, _ := json.Marshal(raw)
+		if err := json.Unmarshal(quoted, &k); err != nil {
+			// numeric keys are quoted in JSON objects
+			if err := json.Unmarshal([]byte(raw), &k); err != nil {
+				return err
+			}
+		}
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		ret.Set(k, v)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	*m = ret
+	return nil
+}
+
+// AssociateOrdered returns an ordered map containing key-value pairs returned
+// by the given function applied to the elements of the given slice. Keys are
+// ordered by their first occurrence, while later values overwrite earlier
+// ones, as in Associate.
+func AssociateOrdered[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V),
+) *OrderedMap[K, V] {
+	ret := NewOrderedMap[K, V]()
+	for _, e := range s {
+		ret.Set(fn(e))
+	}
+	return ret
+}
+
+// GroupByOrdered returns an ordered map containing key to list of values
+// returned by the given function applied to the elements of the given slice.
+// Keys are ordered by their first occurrence.
+func GroupByOrdered[T, V any, K comparable](
+	s []T,
+	fn func(T) (K, V),
+) *OrderedMap[K, []V] {
+	ret := NewOrderedMap[K, []V]()
+	for _, e := range s {
+		k, v := fn(e)
+		lst, _ := ret.Get(k)
+		ret.Set(k, append(lst, v))
+	}
+	return ret
+}
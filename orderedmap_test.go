@@ -0,0 +1,172 @@
+package fun
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	var m OrderedMap[string, int]
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10) // update keeps position
+
+	if got := m.Keys(); !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Errorf("Keys() = %v, want [c a b]", got)
+	}
+	if got := m.Values(); !reflect.DeepEqual(got, []int{3, 10, 2}) {
+		t.Errorf("Values() = %v, want [3 10 2]", got)
+	}
+	if v, ok := m.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(a) = %v, %v, want 10, true", v, ok)
+	}
+	if _, ok := m.Get("z"); ok || m.Has("z") {
+		t.Errorf("Get(z) found a missing key")
+	}
+
+	if !m.Delete("c") || m.Delete("c") {
+		t.Errorf("Delete(c) did not report presence correctly")
+	}
+	m.Set("c", 30) // re-insert moves to the end
+	want := []*Pair[string, int]{{"a", 10}, {"b", 2}, {"c", 30}}
+	if got := m.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if m.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", m.Len())
+	}
+	if got := m.String(); got != "{a:10 b:2 c:30}" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := m.ToMap(); !reflect.DeepEqual(got, map[string]int{"a": 10, "b": 2, "c": 30}) {
+		t.Errorf("ToMap() = %v", got)
+	}
+
+	// deleting from the middle and both ends keeps the links intact
+	m.Set("d", 4)
+	m.Delete("b")
+	m.Delete("a")
+	m.Delete("d")
+	if got := m.Keys(); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("Keys() after deletes = %v, want [c]", got)
+	}
+}
+
+func TestOrderedMapAll(t *testing.T) {
+	m := NewOrderedMap[int, string]()
+	for _, k := range []int{5, 3, 9, 1} {
+		m.Set(k, string(rune('a'+k)))
+	}
+	var keys []int
+	for k := range m.All() {
+		keys = append(keys, k)
+		if k == 9 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(keys, []int{5, 3, 9}) {
+		t.Errorf("All() = %v, want [5 3 9]", keys)
+	}
+	sum := FoldItemsSeq(m.All(), 0, func(acc, k int, _ string) int { return acc + k })
+	if sum != 18 {
+		t.Errorf("FoldItemsSeq(All()) = %d, want 18", sum)
+	}
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	m := NewOrderedMap[string, []int]()
+	m.Set("zeta", []int{1})
+	m.Set("alpha", []int{2, 3})
+	m.Set("mid", nil)
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"zeta":[1],"alpha":[2,3],"mid":null}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var got OrderedMap[string, []int]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Items(), m.Items()) {
+		t.Errorf("json round trip = %v, want %v", got.Items(), m.Items())
+	}
+
+	ints := NewOrderedMap[int, bool]()
+	ints.Set(10, true)
+	ints.Set(-2, false)
+	data, err = json.Marshal(ints)
+	if err != nil || string(data) != `{"10":true,"-2":false}` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+	var gotInts OrderedMap[int, bool]
+	if err := json.Unmarshal(data, &gotInts); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotInts.Keys(), []int{10, -2}) {
+		t.Errorf("json.Unmarshal() keys = %v, want [10 -2]", gotInts.Keys())
+	}
+
+	// keys with control characters, which Go and JSON escape differently
+	odd := NewOrderedMap[string, int]()
+	odd.Set("a\x7fb", 1)
+	odd.Set("tab\there", 2)
+	odd.Set("nl\n\u2028\"q\"", 3)
+	data, err = json.Marshal(odd)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var gotOdd OrderedMap[string, int]
+	if err := json.Unmarshal(data, &gotOdd); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+	}
+	if !reflect.DeepEqual(gotOdd.Items(), odd.Items()) {
+		t.Errorf("json round trip = %v, want %v", gotOdd.Items(), odd.Items())
+	}
+
+	if err := json.Unmarshal([]byte(`[1, 2]`), &gotInts); err == nil {
+		t.Errorf("json.Unmarshal() of an array did not fail")
+	}
+	type key struct{ A int }
+	bad := NewOrderedMap[key, int]()
+	bad.Set(key{1}, 1)
+	if _, err := json.Marshal(bad); err == nil {
+		t.Errorf("json.Marshal() with struct keys did not fail")
+	}
+}
+
+func TestOrderedMapOf(t *testing.T) {
+	m := OrderedMapOf(map[string]int{"b": 2, "c": 3, "a": 1})
+	if got := m.Keys(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("OrderedMapOf() keys = %v, want [a b c]", got)
+	}
+}
+
+func TestGroupByAndAssociateOrdered(t *testing.T) {
+	words := []string{"ccc", "a", "bb", "dd", "e", "fff"}
+	groups := GroupByOrdered(words, func(s string) (int, string) { return len(s), s })
+	wantGroups := []*Pair[int, []string]{
+		{3, []string{"ccc", "fff"}},
+		{1, []string{"a", "e"}},
+		{2, []string{"bb", "dd"}},
+	}
+	if got := groups.Items(); !reflect.DeepEqual(got, wantGroups) {
+		t.Errorf("GroupByOrdered() = %v, want %v", got, wantGroups)
+	}
+	if !reflect.DeepEqual(groups.ToMap(), GroupBy(words, func(s string) (int, string) {
+		return len(s), s
+	})) {
+		t.Errorf("GroupByOrdered() does not match GroupBy()")
+	}
+
+	assoc := AssociateOrdered(words, func(s string) (int, string) { return len(s), s })
+	wantAssoc := []*Pair[int, string]{{3, "fff"}, {1, "e"}, {2, "dd"}}
+	if got := assoc.Items(); !reflect.DeepEqual(got, wantAssoc) {
+		t.Errorf("AssociateOrdered() = %v, want %v", got, wantAssoc)
+	}
+}
@@ -0,0 +1,122 @@
+package fun
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelMap returns the slice obtained after applying the given function
+// over every element in the given slice, using at most limit goroutines.
+// A non-positive limit uses runtime.GOMAXPROCS(0) goroutines.
+// Results are in the same order as the input. If the function panics, the
+// remaining elements are abandoned and the first panic is re-raised in the
+// calling goroutine.
+func ParallelMap[T1, T2 any](s []T1, limit int, fn func(T1) T2) []T2 {
+	ret := make([]T2, len(s))
+	parallelFor(context.Background(), len(s), limit, func(i int) {
+		ret[i] = fn(s[i])
+	})
+	return ret
+}
+
+// ParallelFilter returns the slice obtained after retaining only those
+// elements in the given slice for which the given function returns true,
+// evaluating the predicate using at most limit goroutines.
+// Elements retain their original order. Panics are handled as in ParallelMap.
+func ParallelFilter[T any](s []T, limit int, fn func(T) bool) []T {
+	return ParallelFilterMap(s, limit, func(e T) (T, bool) {
+		return e, fn(e)
+	})
+}
+
+// ParallelFilterMap returns the slice obtained after both filtering and
+// mapping using the given function, which is run using at most limit
+// goroutines. The function should return the result of the mapping operation
+// and whether the element should be included or not.
+// Results retain the input order. Panics are handled as in ParallelMap.
+func ParallelFilterMap[T1, T2 any](
+	s []T1,
+	limit int,
+	fn func(T1) (T2, bool),
+) []T2 {
+	mapped := make([]T2, len(s))
+	keep := make([]bool, len(s))
+	parallelFor(context.Background(), len(s), limit, func(i int) {
+		mapped[i], keep[i] = fn(s[i])
+	})
+	ret := make([]T2, 0)
+	for i, m := range mapped {
+		if keep[i] {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+// ParallelFlatMap applies the given function to each element of the given
+// slice using at most limit goroutines, and combines the resulting slices into
+// one, in input order. Panics are handled as in ParallelMap.
+func ParallelFlatMap[T1, T2 any](s []T1, limit int, fn func(T1) []T2) []T2 {
+	parts := ParallelMap(s, limit, fn)
+	ret := make([]T2, 0)
+	for _, p := range parts {
+		ret = append(ret, p...)
+	}
+	return ret
+}
+
+// parallelFor invokes fn for every index in [0, n) using at most limit
+// goroutines. Once any invocation panics no new indices are started, and the
+// first recovered panic value is re-raised after all goroutines have exited.
+// Likewise no new indices are started once ctx is done, in which case
+// ctx.Err() is returned.
+func parallelFor(ctx context.Context, n, limit int, fn func(int)) error {
+	if n == 0 {
+		return ctx.Err()
+	}
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if limit > n {
+		limit = n
+	}
+
+	var (
+		next     atomic.Int64
+		failed   atomic.Bool
+		panicked any
+		once     sync.Once
+		wg       sync.WaitGroup
+	)
+	worker := func() {
+		defer wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() { panicked = r })
+				failed.Store(true)
+			}
+		}()
+		for !failed.Load() && ctx.Err() == nil {
+			i := int(next.Add(1) - 1)
+			if i >= n {
+				return
+			}
+			fn(i)
+		}
+	}
+	wg.Add(limit)
+	for w := 0; w < limit; w++ {
+		go worker()
+	}
+	wg.Wait()
+	if failed.Load() {
+		panic(panicked)
+	}
+	if int(next.Load()) < n {
+		// stopped early without a panic, so ctx must be done
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package fun
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMatchesSequential(t *testing.T) {
+	input := make([]int, 500)
+	for i := range input {
+		input[i] = i
+	}
+	square := func(i int) int { return i * i }
+	isEven := func(i int) bool { return i%2 == 0 }
+	evenToString := func(i int) (string, bool) { return fmt.Sprint(i), isEven(i) }
+	repeat := func(i int) []int { return []int{i, i % 7} }
+	for _, limit := range []int{0, 1, 3, 16, 1000} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			if got, want := ParallelMap(input, limit, square), Map(input, square); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParallelMap() = %v, want %v", got, want)
+			}
+			if got, want := ParallelFilter(input, limit, isEven), Filter(input, isEven); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParallelFilter() = %v, want %v", got, want)
+			}
+			if got, want := ParallelFilterMap(input, limit, evenToString), FilterMap(input, evenToString); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParallelFilterMap() = %v, want %v", got, want)
+			}
+			if got, want := ParallelFlatMap(input, limit, repeat), FlatMap(input, repeat); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParallelFlatMap() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParallelEmpty(t *testing.T) {
+	if got := ParallelMap([]int{}, 4, func(i int) int { return i }); !reflect.DeepEqual(got, []int{}) {
+		t.Errorf("ParallelMap() = %v, want []", got)
+	}
+	if got := ParallelFilter([]int{}, 4, func(i int) bool { return true }); !reflect.DeepEqual(got, []int{}) {
+		t.Errorf("ParallelFilter() = %v, want []", got)
+	}
+}
+
+func TestParallelMapRespectsLimit(t *testing.T) {
+	const limit = 3
+	var active, maxActive atomic.Int32
+	input := make([]int, 50)
+	ParallelMap(input, limit, func(i int) int {
+		n := active.Add(1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+		return i
+	})
+	if got := maxActive.Load(); got > limit {
+		t.Errorf("ParallelMap() ran %d callbacks concurrently, limit was %d", got, limit)
+	}
+}
+
+func TestParallelMapPropagatesPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r != "boom" {
+			t.Errorf("ParallelMap() panicked with %v, want boom", r)
+		}
+	}()
+	input := make([]int, 100)
+	for i := range input {
+		input[i] = i
+	}
+	ParallelMap(input, 4, func(i int) int {
+		if i == 42 {
+			panic("boom")
+		}
+		return i
+	})
+	t.Errorf("ParallelMap() did not panic")
+}
@@ -0,0 +1,392 @@
+package fun
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// The tests below check the nil and aliasing policy described in the package
+// documentation for every exported function that returns a slice or a map,
+// except the InPlace and Into functions, which return the slice or map they
+// are given, and the MarshalJSON methods.
+
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func TestEmptyResultsAreNotNil(t *testing.T) {
+	var (
+		ints   []int
+		none   = func(int) bool { return false }
+		all    = func(int) bool { return true }
+		kv     = func(i int) (int, int) { return i, i }
+		id     = func(i int) int { return i }
+		ok     = func(i int) (int, bool) { return i, true }
+		some   = func(int) []int { return nil }
+		pairs  []*Pair[int, int]
+		ctx    = context.Background()
+		byInt  = ComparingBy(id)
+		oneMap map[int]int
+		groups map[int][]int
+		first  = func(a, b int) int { return a }
+		first3 = func(_, a, b int) int { return a }
+		kvErr  = func(i int) (int, int, error) { return i, i, nil }
+	)
+	mm := AsMultimap(groups)
+	sm := make(SetMultimap[int, int])
+	om := NewOrderedMap[int, int]()
+	// each case returns every slice and map result of one function, called
+	// with nil inputs
+	cases := map[string]func() []any{
+		"Associate":        func() []any { return []any{Associate(ints, kv)} },
+		"AssociateOrdered": func() []any { return []any{AssociateOrdered(ints, kv).Keys()} },
+		"AssociateBy":      func() []any { return []any{AssociateBy(ints, func(i int) int { return i })} },
+		"AssociateWith":    func() []any { return []any{AssociateWith(ints, func(i int) int { return i })} },
+		"AssociateResolve": func() []any { return []any{AssociateResolve(ints, kv, KeepFirst)} },
+		"AssociateUnique": func() []any {
+			m, _ := AssociateUnique(ints, kv)
+			return []any{m}
+		},
+		"AssociateErr": func() []any {
+			a, _ := AssociateErr(ints, kvErr)
+			b, _ := AssociateErrAll(ints, kvErr)
+			return []any{a, b}
+		},
+		"Chan": func() []any {
+			done, cancel := context.WithCancel(ctx)
+			cancel()
+			return []any{FanOut(done, make(chan int), 1), Tee(done, make(chan int), 1)}
+		},
+		"Chunked":       func() []any { return []any{Chunked(ints, 2)} },
+		"ChunkedBy":     func() []any { return []any{ChunkedBy(ints, func(a, b int) bool { return true })} },
+		"ChunkedView":   func() []any { return []any{ChunkedView(ints, 2)} },
+		"Collect":       func() []any { return []any{Collect(AsSeq(ints))} },
+		"Distinct":      func() []any { return []any{Distinct(ints)} },
+		"DistinctBy":    func() []any { return []any{DistinctBy(ints, id), DistinctBySet(ints, id)} },
+		"Drop":          func() []any { return []any{Drop(ints, 1), DropCloned(ints, 1)} },
+		"DropLast":      func() []any { return []any{DropLast(ints, 1), DropLastCloned(ints, 1)} },
+		"DropLastWhile": func() []any { return []any{DropLastWhile(ints, all), DropLastWhileCloned(ints, all)} },
+		"DropWhile":     func() []any { return []any{DropWhile(ints, all), DropWhileCloned(ints, all)} },
+		"Filter": func() []any {
+			return []any{Filter(ints, none), FilterIndexed(ints, func(int, int) bool { return true })}
+		},
+		"FilterErr": func() []any {
+			a, _ := FilterErr(ints, func(int) (bool, error) { return true, nil })
+			b, _ := FilterErrAll(ints, func(int) (bool, error) { return true, nil })
+			return []any{a, b}
+		},
+		"FilterMapErr": func() []any {
+			a, _ := FilterMapErr(ints, func(i int) (int, bool, error) { return i, true, nil })
+			b, _ := FilterMapErrAll(ints, func(i int) (int, bool, error) { return i, true, nil })
+			return []any{a, b}
+		},
+		"FilterMap": func() []any { return []any{FilterMap(ints, ok)} },
+		"FlatMap": func() []any {
+			return []any{FlatMap(ints, some), FlatMapIndexed(ints, func(int, int) []int { return nil })}
+		},
+		"GroupBy": func() []any { return []any{GroupBy(ints, kv)} },
+		"GroupByErr": func() []any {
+			a, _ := GroupByErr(ints, kvErr)
+			b, _ := GroupByErrAll(ints, kvErr)
+			return []any{a, b}
+		},
+		"GroupByOrdered": func() []any { return []any{GroupByOrdered(ints, kv).Values()} },
+		"Histogram": func() []any {
+			return []any{
+				Histogram(ints, 2), HistogramEdges(ints, []float64{0, 1}),
+				HistogramBy(ints, id, 2), HistogramEdgesBy(ints, id, []float64{0, 1}),
+			}
+		},
+		"Items": func() []any { return []any{Items(oneMap)} },
+		"Joins": func() []any {
+			return []any{
+				InnerJoin(ints, ints, id, id), LeftJoin(ints, ints, id, id),
+				RightJoin(ints, ints, id, id), FullOuterJoin(ints, ints, id, id),
+				GroupJoin(ints, ints, id, id),
+				// unmatched left elements are paired with an empty group
+				GroupJoin([]int{1}, ints, id, id)[0].Snd,
+				MergeGroupJoin([]int{1}, ints, id, id)[0].Snd,
+				MergeInnerJoin(ints, ints, id, id), MergeLeftJoin(ints, ints, id, id),
+				MergeRightJoin(ints, ints, id, id), MergeFullOuterJoin(ints, ints, id, id),
+				MergeGroupJoin(ints, ints, id, id),
+				InnerJoinWith(ints, ints, id, id, func(l, r int) int { return l }),
+				LeftJoinWith(ints, ints, id, id, func(l int, _ Option[int]) int { return l }),
+				RightJoinWith(ints, ints, id, id, func(_ Option[int], r int) int { return r }),
+				FullOuterJoinWith(ints, ints, id, id, func(Option[int], Option[int]) int { return 0 }),
+				GroupJoinWith(ints, ints, id, id, func(l int, _ []int) int { return l }),
+			}
+		},
+		"Maps": func() []any {
+			return []any{
+				Keys(oneMap), Values(oneMap), SortedKeys(oneMap), SortedItems(oneMap),
+				MapKeys(oneMap, id), MapValues(oneMap, id),
+				FilterKeys(oneMap, all), FilterValues(oneMap, all),
+				Invert(oneMap, func(_, a, b int) int { return a }), InvertToGroup(oneMap),
+				PickKeys(oneMap, 1), OmitKeys(oneMap, 1), FromPairs(pairs),
+			}
+		},
+		"Map":    func() []any { return []any{Map(ints, id), MapIndexed(ints, func(_, i int) int { return i })} },
+		"MapCtx": func() []any { a, _ := MapCtx(ctx, ints, id); return []any{a} },
+		"MapErr": func() []any {
+			a, _ := MapErr(ints, func(i int) (int, error) { return i, nil })
+			b, _ := MapErrAll(ints, func(i int) (int, error) { return i, nil })
+			return []any{a, b}
+		},
+		"MapResult": func() []any {
+			rs := MapResult(ints, func(i int) (int, error) { return i, nil })
+			vs, errs := PartitionResults(rs)
+			collected, _ := CollectResults(rs)
+			return []any{rs, vs, errs, collected}
+		},
+		"Merge": func() []any {
+			var tree map[string]any
+			unflattened, _ := Unflatten(nil, ".")
+			return []any{
+				Merge[map[int]int](nil), Merge(first3, oneMap, oneMap),
+				MergeGroups[map[int][]int](), MergeGroups(groups, groups),
+				DeepMerge(nil), DeepMerge(AppendLists, tree, tree),
+				ReplaceLists("", nil, nil), AppendLists("", nil, nil),
+				UnionListsBy(func(e any) any { return e })("", nil, nil),
+				Flatten(tree, "."), unflattened,
+			}
+		},
+		"Mode": func() []any { return []any{Mode(ints), ModeBy(ints, id)} },
+		"Multimap": func() []any {
+			return []any{
+				mm.Get(1), mm.RemoveAll(1), mm.Keys(), mm.Entries(),
+				mm.Inverse(), mm.ToSetMultimap(),
+				sm.Get(1), sm.RemoveAll(1), sm.Keys(), sm.Entries(), sm.Inverse(),
+			}
+		},
+		"OrderedMap": func() []any { return []any{om.Keys(), om.Values(), om.Items(), om.ToMap()} },
+		"Parallel": func() []any {
+			return []any{
+				ParallelMap(ints, 0, id), ParallelFilter(ints, 0, all),
+				ParallelFilterMap(ints, 0, ok), ParallelFlatMap(ints, 0, some),
+			}
+		},
+		"ParallelMapCtx": func() []any { a, _ := ParallelMapCtx(ctx, ints, 0, id); return []any{a} },
+		"Partition": func() []any {
+			a, b := Partition(ints, all)
+			c, d := PartitionSeq(AsSeq(ints), all)
+			return []any{a, b, c, d}
+		},
+		"Reversed": func() []any { return []any{Reversed(ints)} },
+		"Seq": func() []any {
+			return []any{
+				GroupBySeq(AsSeq(ints), kv), AssociateSeq(AsSeq(ints), kv),
+				ToMap(maps.All(oneMap)),
+			}
+		},
+		"Set": func() []any {
+			s := DistinctSet(ints)
+			return []any{
+				s, s.ToSlice(), SortedSlice(s), SortedSliceFunc(s, first),
+				NewSet[int](), SetOf(ints), s.Clone(), s.Union(s), s.Intersection(s),
+				s.Difference(s), s.SymmetricDifference(s),
+			}
+		},
+		"Sorted": func() []any {
+			return []any{
+				SortedBy(ints, id), SortedByDescending(ints, id),
+				SortedStableBy(ints, id), SortedStableByDescending(ints, id),
+				SortedWith(ints, byInt), SortedStableWith(ints, byInt),
+				SortedWithKey(ints, id, byInt), SortedStableWithKey(ints, id, byInt),
+			}
+		},
+		"Take":          func() []any { return []any{Take(ints, 1), TakeCloned(ints, 1)} },
+		"TakeLast":      func() []any { return []any{TakeLast(ints, 1), TakeLastCloned(ints, 1)} },
+		"TakeLastWhile": func() []any { return []any{TakeLastWhile(ints, all), TakeLastWhileCloned(ints, all)} },
+		"TakeWhile":     func() []any { return []any{TakeWhile(ints, all), TakeWhileCloned(ints, all)} },
+		"Summarize":     func() []any { return []any{SummarizeGroups(groups, id)} },
+		"TransformMap": func() []any {
+			same := func(k, v int) (int, int, bool) { return k, v, true }
+			sameErr := func(k, v int) (int, int, bool, error) { return k, v, true, nil }
+			unique, _ := TransformMapUnique(oneMap, same)
+			a, _ := TransformMapErr(oneMap, sameErr)
+			b, _ := TransformMapErrAll(oneMap, sameErr)
+			return []any{
+				TransformMap(oneMap, same), TransformMapWith(oneMap, same, KeepFirst),
+				TransformMapToGroup(oneMap, same), unique, a, b,
+			}
+		},
+		"Unzip": func() []any {
+			a, b := Unzip(pairs)
+			c, d := UnzipSeq(AsSeq(pairs))
+			return []any{a, b, c, d}
+		},
+		"Unzip3": func() []any { a, b, c := Unzip3([]*Triple[int, int, int](nil)); return []any{a, b, c} },
+		"Unzip4": func() []any {
+			a, b, c, d := Unzip4([]*Quad[int, int, int, int](nil))
+			return []any{a, b, c, d}
+		},
+		"Unzip5": func() []any {
+			a, b, c, d, e := Unzip5([]*Quint[int, int, int, int, int](nil))
+			return []any{a, b, c, d, e}
+		},
+		"Windowed":     func() []any { return []any{Windowed(ints, 2, 1), WindowedView(ints, 2, 1)} },
+		"WindowedOpts": func() []any { return []any{WindowedOpts(ints, 2, 1), WindowedTransform(ints, 2, 1, Sum[int])} },
+		"Zip": func() []any {
+			return []any{
+				Zip(ints, ints), Zip3(ints, ints, ints), Zip4(ints, ints, ints, ints),
+				Zip5(ints, ints, ints, ints, ints),
+			}
+		},
+		"ZipWith": func() []any {
+			return []any{
+				ZipWith(ints, ints, func(a, b int) int { return a }),
+				ZipWith3(ints, ints, ints, func(a, _, _ int) int { return a }),
+				ZipWith4(ints, ints, ints, ints, func(a, _, _, _ int) int { return a }),
+				ZipWith5(ints, ints, ints, ints, ints, func(a, _, _, _, _ int) int { return a }),
+			}
+		},
+		"CheckedTakeLast": func() []any {
+			a, _ := TakeLastChecked(ints, 1)
+			b, _ := DropLastChecked(ints, 1)
+			return []any{a, b}
+		},
+		"CheckedTake":   func() []any { a, _ := TakeChecked(ints, 1); b, _ := DropChecked(ints, 1); return []any{a, b} },
+		"CheckedChunks": func() []any { a, _ := ChunkedChecked(ints, 1); b, _ := WindowedChecked(ints, 1, 1); return []any{a, b} },
+	}
+	for name, fn := range cases {
+		for i, v := range fn() {
+			if isNil(v) {
+				t.Errorf("%s: result %d is nil, want empty", name, i)
+			}
+		}
+	}
+}
+
+func TestAliasing(t *testing.T) {
+	all := func(int) bool { return true }
+	// each case returns a non-empty result of one function, called with the
+	// given slice, and whether that result should share the slice's storage
+	cases := map[string]struct {
+		fn    func(s []int) []int
+		alias bool
+	}{
+		"Take":                {func(s []int) []int { return Take(s, 2) }, true},
+		"TakeLast":            {func(s []int) []int { return TakeLast(s, 2) }, true},
+		"TakeWhile":           {func(s []int) []int { return TakeWhile(s, all) }, true},
+		"TakeLastWhile":       {func(s []int) []int { return TakeLastWhile(s, all) }, true},
+		"Drop":                {func(s []int) []int { return Drop(s, 1) }, true},
+		"DropLast":            {func(s []int) []int { return DropLast(s, 1) }, true},
+		"DropWhile":           {func(s []int) []int { return DropWhile(s, func(i int) bool { return i < 2 }) }, true},
+		"DropLastWhile":       {func(s []int) []int { return DropLastWhile(s, func(i int) bool { return i > 2 }) }, true},
+		"ChunkedView":         {func(s []int) []int { return ChunkedView(s, 2)[0] }, true},
+		"Multimap.Get":        {func(s []int) []int { return Multimap[int, int]{1: s}.Get(1) }, true},
+		"WindowedView":        {func(s []int) []int { return WindowedView(s, 2, 1)[0] }, true},
+		"TakeCloned":          {func(s []int) []int { return TakeCloned(s, 2) }, false},
+		"TakeLastCloned":      {func(s []int) []int { return TakeLastCloned(s, 2) }, false},
+		"TakeWhileCloned":     {func(s []int) []int { return TakeWhileCloned(s, all) }, false},
+		"TakeLastWhileCloned": {func(s []int) []int { return TakeLastWhileCloned(s, all) }, false},
+		"DropCloned":          {func(s []int) []int { return DropCloned(s, 1) }, false},
+		"DropLastCloned":      {func(s []int) []int { return DropLastCloned(s, 1) }, false},
+		"DropWhileCloned": {func(s []int) []int {
+			return DropWhileCloned(s, func(i int) bool { return i < 2 })
+		}, false},
+		"DropLastWhileCloned": {func(s []int) []int {
+			return DropLastWhileCloned(s, func(i int) bool { return i > 2 })
+		}, false},
+		"Chunked":         {func(s []int) []int { return Chunked(s, 2)[0] }, false},
+		"ChunkedBy":       {func(s []int) []int { return ChunkedBy(s, func(a, b int) bool { return true })[0] }, false},
+		"Distinct":        {Distinct[int], false},
+		"Filter":          {func(s []int) []int { return Filter(s, all) }, false},
+		"FlatMap":         {func(s []int) []int { return FlatMap(s, func(i int) []int { return []int{i} }) }, false},
+		"Map":             {func(s []int) []int { return Map(s, func(i int) int { return i }) }, false},
+		"Partition":       {func(s []int) []int { ret, _ := Partition(s, all); return ret }, false},
+		"Reversed":        {Reversed[int], false},
+		"SortedBy":        {func(s []int) []int { return SortedBy(s, func(i int) int { return i }) }, false},
+		"Windowed":        {func(s []int) []int { return Windowed(s, 2, 1)[0] }, false},
+		"WindowedOpts":    {func(s []int) []int { return WindowedOpts(s, 2, 1)[0] }, false},
+		"ParallelFilter":  {func(s []int) []int { return ParallelFilter(s, 0, all) }, false},
+		"ParallelFlatMap": {func(s []int) []int { return ParallelFlatMap(s, 0, func(i int) []int { return []int{i} }) }, false},
+	}
+	for name, tt := range cases {
+		s := []int{1, 2, 3, 4}
+		orig := slices.Clone(s)
+		got := tt.fn(s)
+		if len(got) == 0 {
+			t.Fatalf("%s: empty result", name)
+		}
+		shares := false
+		for i := range s {
+			if &s[i] == &got[0] {
+				shares = true
+			}
+		}
+		if shares != tt.alias {
+			t.Errorf("%s: result shares storage with input = %v, want %v", name, shares, tt.alias)
+		}
+		// appending to a result never overwrites the input
+		_ = append(got, 100)
+		if !slices.Equal(s, orig) {
+			t.Errorf("%s: appending to the result changed the input to %v", name, s)
+		}
+	}
+}
+
+func TestMapAliasing(t *testing.T) {
+	all := func(string) bool { return true }
+	// each case returns a result built from the given map, which must not
+	// share storage with it
+	cases := map[string]func(m map[string]int) map[string]int{
+		"FilterKeys":   func(m map[string]int) map[string]int { return FilterKeys(m, all) },
+		"FilterValues": func(m map[string]int) map[string]int { return FilterValues(m, func(int) bool { return true }) },
+		"MapValues":    func(m map[string]int) map[string]int { return MapValues(m, func(v int) int { return v }) },
+		"PickKeys":     func(m map[string]int) map[string]int { return PickKeys(m, "a", "b") },
+		"OmitKeys":     func(m map[string]int) map[string]int { return OmitKeys(m, "z") },
+		"Merge":        func(m map[string]int) map[string]int { return Merge(nil, m) },
+		"TransformMap": func(m map[string]int) map[string]int {
+			return TransformMap(m, func(k string, v int) (string, int, bool) { return k, v, true })
+		},
+		"OrderedMap.ToMap": func(m map[string]int) map[string]int { return OrderedMapOf(m).ToMap() },
+	}
+	for name, fn := range cases {
+		m := map[string]int{"a": 1, "b": 2}
+		got := fn(m)
+		got["a"] = 100
+		got["c"] = 3
+		if !reflect.DeepEqual(m, map[string]int{"a": 1, "b": 2}) {
+			t.Errorf("%s: changing the result changed the input to %v", name, m)
+		}
+	}
+
+	// the groups of MergeGroups are new slices
+	groups := map[string][]int{"a": {1, 2}}
+	merged := MergeGroups(groups)
+	merged["a"][0] = 100
+	merged["a"] = append(merged["a"], 3)
+	if !reflect.DeepEqual(groups, map[string][]int{"a": {1, 2}}) {
+		t.Errorf("MergeGroups: changing the result changed the input to %v", groups)
+	}
+
+	// the nested maps and lists of DeepMerge are copies
+	tree := map[string]any{"a": map[string]any{"b": 1}, "l": []any{1, 2}}
+	deep := DeepMerge(nil, tree)
+	deep["a"].(map[string]any)["b"] = 100
+	deep["l"].([]any)[0] = 100
+	want := map[string]any{"a": map[string]any{"b": 1}, "l": []any{1, 2}}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("DeepMerge: changing the result changed the input to %v", tree)
+	}
+
+	// a Set's Clone and set operations return new sets
+	s := NewSet(1, 2)
+	for name, got := range map[string]Set[int]{
+		"Clone": s.Clone(), "Union": s.Union(NewSet[int]()), "Difference": s.Difference(NewSet[int]()),
+	} {
+		got.Add(3)
+		if !s.Equal(NewSet(1, 2)) {
+			t.Errorf("Set.%s: changing the result changed the input to %v", name, s)
+		}
+	}
+}
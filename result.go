@@ -0,0 +1,161 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Result represents either a successfully computed value or the error that
+// prevented it from being computed.
+// The zero value is a successful Result holding the zero value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding the given value
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result holding the given error. It panics if the error
+// is nil, since such a Result could not be told apart from a successful one;
+// use ResultOf to build a Result from an error that may be nil.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic(errors.New("fun: Err called with a nil error"))
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf returns a Result from the usual (value, error) pair returned by
+// Go functions
+func ResultOf[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// Get returns the value and error held by the Result
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Err returns the error held by the Result, or nil if it is successful
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// IsOk returns true if the Result holds a value
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr returns true if the Result holds an error
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Unwrap returns the value held by the Result, and panics with the held error
+// if it failed
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value held by the Result, or the given value if it
+// failed
+func (r Result[T]) UnwrapOr(v T) T {
+	if r.err != nil {
+		return v
+	}
+	return r.value
+}
+
+// Option returns an Option holding the value of a successful Result, or an
+// empty Option if it failed
+func (r Result[T]) Option() Option[T] {
+	if r.err != nil {
+		return None[T]()
+	}
+	return Some(r.value)
+}
+
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// ResultMap returns a Result holding the result of applying the given function
+// to the value of the given Result, or the original error if it failed
+func ResultMap[T1, T2 any](r Result[T1], fn func(T1) T2) Result[T2] {
+	if r.err != nil {
+		return Err[T2](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// ResultAndThen returns the Result returned by the given function applied to
+// the value of the given Result, or the original error if it failed
+func ResultAndThen[T1, T2 any](r Result[T1], fn func(T1) Result[T2]) Result[T2] {
+	if r.err != nil {
+		return Err[T2](r.err)
+	}
+	return fn(r.value)
+}
+
+// MapResult returns the slice of Results obtained after applying the given
+// function over every element in the given slice. Unlike MapErr, it does not
+// stop at a failure; each element succeeds or fails independently.
+func MapResult[T1, T2 any](s []T1, fn func(T1) (T2, error)) []Result[T2] {
+	return Map(s, func(e T1) Result[T2] {
+		return ResultOf(fn(e))
+	})
+}
+
+// PartitionResults returns two slices where the first slice contains the
+// values of the successful Results and the second slice contains the errors
+// of the failed ones, both in their original order.
+func PartitionResults[T any](rs []Result[T]) ([]T, []error) {
+	values := make([]T, 0)
+	errs := make([]error, 0)
+	for _, r := range rs {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		} else {
+			values = append(values, r.value)
+		}
+	}
+	return values, errs
+}
+
+// CollectResults returns the values of all the given Results if they all
+// succeeded. Otherwise it returns a nil slice and the error of the first
+// failed Result.
+func CollectResults[T any](rs []Result[T]) ([]T, error) {
+	ret := make([]T, 0, len(rs))
+	for _, r := range rs {
+		if r.err != nil {
+			return nil, r.err
+		}
+		ret = append(ret, r.value)
+	}
+	return ret, nil
+}
+
+// FoldResults accumulates the values of the given Results starting with the
+// given initial value, stopping at the first failed Result and returning its
+// error along with the accumulator so far, like FoldErr.
+func FoldResults[T, R any](rs []Result[T], initial R, fn func(R, T) R) (R, error) {
+	return FoldErr(rs, initial, func(acc R, r Result[T]) (R, error) {
+		if r.err != nil {
+			return acc, r.err
+		}
+		return fn(acc, r.value), nil
+	})
+}
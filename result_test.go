@@ -0,0 +1,122 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	errBad := errors.New("bad")
+	ok := Ok(42)
+	bad := Err[int](errBad)
+
+	if v, err := ok.Get(); v != 42 || err != nil {
+		t.Errorf("Ok(42).Get() = %v, %v, want 42, nil", v, err)
+	}
+	if _, err := bad.Get(); err != errBad {
+		t.Errorf("Err().Get() error = %v, want %v", err, errBad)
+	}
+	if !ok.IsOk() || ok.IsErr() || bad.IsOk() || !bad.IsErr() {
+		t.Errorf("IsOk/IsErr mismatch for %v and %v", ok, bad)
+	}
+	if ok.Err() != nil || bad.Err() != errBad {
+		t.Errorf("Err() = %v, %v, want nil, %v", ok.Err(), bad.Err(), errBad)
+	}
+	if got := ok.Unwrap(); got != 42 {
+		t.Errorf("Ok(42).Unwrap() = %v, want 42", got)
+	}
+	if got := bad.UnwrapOr(7); got != 7 {
+		t.Errorf("Err().UnwrapOr(7) = %v, want 7", got)
+	}
+	if got := ok.UnwrapOr(7); got != 42 {
+		t.Errorf("Ok(42).UnwrapOr(7) = %v, want 42", got)
+	}
+	if ok.Option() != Some(42) || bad.Option() != None[int]() {
+		t.Errorf("Option() = %v, %v, want Some(42), None", ok.Option(), bad.Option())
+	}
+	if got := fmt.Sprint(ok, " ", bad); got != "Ok(42) Err(bad)" {
+		t.Errorf("String() = %q, want %q", got, "Ok(42) Err(bad)")
+	}
+	if got := ResultOf(strconv.Atoi("x")); got.IsOk() {
+		t.Errorf("ResultOf(strconv.Atoi(x)) = %v, want Err", got)
+	}
+}
+
+func TestResultUnwrapPanicsWithError(t *testing.T) {
+	errBad := errors.New("bad")
+	defer func() {
+		if r := recover(); r != errBad {
+			t.Errorf("Unwrap() panicked with %v, want %v", r, errBad)
+		}
+	}()
+	Err[int](errBad).Unwrap()
+}
+
+func TestErrPanicsOnNil(t *testing.T) {
+	if err := panicErr(func() { Err[int](nil) }); err == nil {
+		t.Errorf("Err(nil) did not panic")
+	}
+	if r := ResultOf(0, nil); !r.IsOk() {
+		t.Errorf("ResultOf(0, nil) = %v, want Ok(0)", r)
+	}
+}
+
+func TestResultMapAndThen(t *testing.T) {
+	errBad := errors.New("bad")
+	double := func(i int) int { return i * 2 }
+	if got := ResultMap(Ok(21), double); got != Ok(42) {
+		t.Errorf("ResultMap(Ok) = %v, want Ok(42)", got)
+	}
+	if got := ResultMap(Err[int](errBad), double); got.Err() != errBad {
+		t.Errorf("ResultMap(Err) = %v, want Err(bad)", got)
+	}
+	parse := func(s string) Result[int] { return ResultOf(strconv.Atoi(s)) }
+	if got := ResultAndThen(Ok("42"), parse); got != Ok(42) {
+		t.Errorf("ResultAndThen(Ok(42)) = %v, want Ok(42)", got)
+	}
+	if got := ResultAndThen(Ok("x"), parse); !errors.Is(got.Err(), strconv.ErrSyntax) {
+		t.Errorf("ResultAndThen(Ok(x)) = %v, want syntax error", got)
+	}
+	if got := ResultAndThen(Err[string](errBad), parse); got.Err() != errBad {
+		t.Errorf("ResultAndThen(Err) = %v, want Err(bad)", got)
+	}
+}
+
+func TestMapResultAndFriends(t *testing.T) {
+	rs := MapResult([]string{"1", "x", "3", "y"}, strconv.Atoi)
+	if len(rs) != 4 || !rs[0].IsOk() || !rs[1].IsErr() || !rs[2].IsOk() || !rs[3].IsErr() {
+		t.Fatalf("MapResult() = %v", rs)
+	}
+
+	values, errs := PartitionResults(rs)
+	if !reflect.DeepEqual(values, []int{1, 3}) || len(errs) != 2 {
+		t.Errorf("PartitionResults() = %v, %v, want [1 3] and 2 errors", values, errs)
+	}
+
+	got, err := CollectResults(rs)
+	if got != nil || err != rs[1].Err() {
+		t.Errorf("CollectResults() = %v, %v, want nil, %v", got, err, rs[1].Err())
+	}
+	got, err = CollectResults(MapResult([]string{"1", "2"}, strconv.Atoi))
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("CollectResults() = %v, %v, want [1 2], nil", got, err)
+	}
+
+	sum := func(acc, v int) int { return acc + v }
+	total, err := FoldResults(rs, 10, sum)
+	if total != 11 || err != rs[1].Err() {
+		t.Errorf("FoldResults() = %v, %v, want 11, %v", total, err, rs[1].Err())
+	}
+	total, err = FoldResults([]Result[int]{Ok(1), Ok(2)}, 0, sum)
+	if total != 3 || err != nil {
+		t.Errorf("FoldResults() = %v, %v, want 3, nil", total, err)
+	}
+
+	values, errs = PartitionResults([]Result[int]{})
+	if !reflect.DeepEqual(values, []int{}) || !reflect.DeepEqual(errs, []error{}) {
+		t.Errorf("PartitionResults(empty) = %#v, %#v, want empty slices", values, errs)
+	}
+}
@@ -0,0 +1,650 @@
+package fun
+
+import "iter"
+
+// AsSeq returns a lazy sequence over the elements of the given slice
+func AsSeq[T any](s []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, e := range s {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// AsSeqIndexed returns a lazy sequence over the index, value pairs of the
+// given slice
+func AsSeqIndexed[T any](s []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, e := range s {
+			if !yield(i, e) {
+				return
+			}
+		}
+	}
+}
+
+// ItemsSeq returns a lazy sequence over the key, value pairs of the given map
+func ItemsSeq[M ~map[K]V, K comparable, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns a slice containing all the elements of the given sequence.
+// An empty sequence results in an empty, non-nil slice, matching the eager
+// functions.
+func Collect[T any](seq iter.Seq[T]) []T {
+	ret := make([]T, 0)
+	for e := range seq {
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+// ToMap returns a map containing all the key, value pairs of the given
+// sequence. Later pairs overwrite earlier ones with the same key.
+func ToMap[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	ret := make(map[K]V)
+	for k, v := range seq {
+		ret[k] = v
+	}
+	return ret
+}
+
+// AllSeq returns true if all elements return true for given predicate.
+// Stops pulling from the sequence at the first element that fails.
+func AllSeq[T any](seq iter.Seq[T], fn func(T) bool) bool {
+	for e := range seq {
+		if !fn(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnySeq returns true if at least one element returns true for given
+// predicate. Stops pulling from the sequence at the first match.
+func AnySeq[T any](seq iter.Seq[T], fn func(T) bool) bool {
+	for e := range seq {
+		if fn(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssociateSeq returns a map containing key-value pairs returned by the given
+// function applied to the elements of the given sequence
+func AssociateSeq[T, V any, K comparable](
+	seq iter.Seq[T],
+	fn func(T) (K, V),
+) map[K]V {
+	ret := make(map[K]V)
+	for e := range seq {
+		k, v := fn(e)
+		ret[k] = v
+	}
+	return ret
+}
+
+// ChunkedSeq lazily splits the sequence into slices, each not exceeding given
+// size. The last slice might have fewer elements than the given size.
+// Like Chunked, it panics if chunkSize is not positive.
+func ChunkedSeq[T any](seq iter.Seq[T], chunkSize int) iter.Seq[[]T] {
+	mustCheck(checkSize(chunkSize))
+	return func(yield func([]T) bool) {
+		// the first chunk grows as elements arrive, so a large chunkSize does
+		// not allocate more than the sequence holds; once a chunk has been
+		// filled, the next ones can be allocated at full size
+		var sub []T
+		full := false
+		for e := range seq {
+			if sub == nil && full {
+				sub = make([]T, 0, chunkSize)
+			}
+			sub = append(sub, e)
+			if len(sub) == chunkSize {
+				if !yield(sub) {
+					return
+				}
+				sub, full = nil, true
+			}
+		}
+		if len(sub) > 0 {
+			yield(sub)
+		}
+	}
+}
+
+// ChunkedBySeq lazily splits the sequence into slices, starting a new slice
+// whenever the given function returns false for the previous and current
+// element
+func ChunkedBySeq[T any](seq iter.Seq[T], fn func(T, T) bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var sub []T
+		for e := range seq {
+			if len(sub) > 0 && !fn(sub[len(sub)-1], e) {
+				if !yield(sub) {
+					return
+				}
+				sub = nil
+			}
+			sub = append(sub, e)
+		}
+		if len(sub) > 0 {
+			yield(sub)
+		}
+	}
+}
+
+// DistinctSeq returns a sequence containing only distinct elements from the
+// given sequence. Elements will retain their original order.
+func DistinctSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return DistinctBySeq(seq, func(e T) T { return e })
+}
+
+// DistinctBySeq returns a sequence containing only distinct elements from the
+// given sequence as distinguished by the given selector function.
+// Elements will retain their original order.
+func DistinctBySeq[T any, K comparable](
+	seq iter.Seq[T],
+	fn func(T) K,
+) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		m := make(map[K]bool)
+		for e := range seq {
+			k := fn(e)
+			if m[k] {
+				continue
+			}
+			m[k] = true
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// DropSeq returns a sequence containing all elements except the first n
+func DropSeq[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for e := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// DropLastSeq returns a sequence containing all elements except the last n.
+// Up to n elements are buffered to find out which ones are last.
+func DropLastSeq[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			for e := range seq {
+				if !yield(e) {
+					return
+				}
+			}
+			return
+		}
+		// ring buffer holding the n most recent elements, grown as elements
+		// arrive so that a large n does not allocate up front
+		var buf []T
+		head := 0
+		for e := range seq {
+			if len(buf) < n {
+				buf = append(buf, e)
+				continue
+			}
+			out := buf[head]
+			buf[head] = e
+			head = (head + 1) % n
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// DropLastWhileSeq returns a sequence containing all elements except the last
+// elements that satisfy the given predicate. Runs of matching elements are
+// buffered until a non-matching element shows they are not last.
+func DropLastWhileSeq[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var pending []T
+		for e := range seq {
+			if fn(e) {
+				pending = append(pending, e)
+				continue
+			}
+			for _, p := range pending {
+				if !yield(p) {
+					return
+				}
+			}
+			pending = pending[:0]
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhileSeq returns a sequence containing all elements except the first
+// elements that satisfy the given predicate
+func DropWhileSeq[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for e := range seq {
+			if dropping && fn(e) {
+				continue
+			}
+			dropping = false
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq returns a sequence retaining only those elements of the given
+// sequence for which the given function returns true
+func FilterSeq[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range seq {
+			if fn(e) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// FilterIndexedSeq returns a sequence retaining only those elements of the
+// given sequence for which the given function returns true. Predicate
+// receives the value as well as its index in the sequence.
+func FilterIndexedSeq[T any](seq iter.Seq[T], fn func(int, T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for e := range seq {
+			if fn(i, e) && !yield(e) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// FilterMapSeq returns a sequence obtained after both filtering and mapping
+// using the given function. The function should return the result of the
+// mapping operation and whether the element should be included or not.
+func FilterMapSeq[T1, T2 any](
+	seq iter.Seq[T1],
+	fn func(T1) (T2, bool),
+) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for e := range seq {
+			m, ok := fn(e)
+			if ok && !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMapSeq returns a sequence of all the elements of the slices obtained by
+// applying the given function to each element of the given sequence
+func FlatMapSeq[T1, T2 any](seq iter.Seq[T1], fn func(T1) []T2) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for e := range seq {
+			for _, m := range fn(e) {
+				if !yield(m) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMapIndexedSeq returns a sequence of all the elements of the slices
+// obtained by applying the given function to each element of the given
+// sequence. The function also receives the index of each element.
+func FlatMapIndexedSeq[T1, T2 any](
+	seq iter.Seq[T1],
+	fn func(int, T1) []T2,
+) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		i := 0
+		for e := range seq {
+			for _, m := range fn(i, e) {
+				if !yield(m) {
+					return
+				}
+			}
+			i++
+		}
+	}
+}
+
+// FoldSeq accumulates values starting with given initial value and applying
+// given function to current accumulator and each element.
+func FoldSeq[T, R any](seq iter.Seq[T], initial R, fn func(R, T) R) R {
+	acc := initial
+	for e := range seq {
+		acc = fn(acc, e)
+	}
+	return acc
+}
+
+// FoldIndexedSeq accumulates values starting with given initial value and
+// applying given function to current accumulator and each element. Function
+// also receives index of current element.
+func FoldIndexedSeq[T, R any](
+	seq iter.Seq[T],
+	initial R,
+	fn func(int, R, T) R,
+) R {
+	acc := initial
+	i := 0
+	for e := range seq {
+		acc = fn(i, acc, e)
+		i++
+	}
+	return acc
+}
+
+// FoldItemsSeq accumulates values starting with given initial value and
+// applying given function to current accumulator and each key, value.
+func FoldItemsSeq[K, V, R any](
+	seq iter.Seq2[K, V],
+	initial R,
+	fn func(R, K, V) R,
+) R {
+	acc := initial
+	for k, v := range seq {
+		acc = fn(acc, k, v)
+	}
+	return acc
+}
+
+// GroupBySeq returns a map containing key to list of values returned by the
+// given function applied to the elements of the given sequence
+func GroupBySeq[T, V any, K comparable](
+	seq iter.Seq[T],
+	fn func(T) (K, V),
+) map[K][]V {
+	ret := make(map[K][]V)
+	for e := range seq {
+		k, v := fn(e)
+		AppendToGroup(ret, k, v)
+	}
+	return ret
+}
+
+// MapSeq returns a sequence obtained after applying the given function over
+// every element in the given sequence
+func MapSeq[T1, T2 any](seq iter.Seq[T1], fn func(T1) T2) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for e := range seq {
+			if !yield(fn(e)) {
+				return
+			}
+		}
+	}
+}
+
+// MapIndexedSeq returns a sequence obtained after applying the given function
+// over every element in the given sequence. The function also receives the
+// index of each element.
+func MapIndexedSeq[T1, T2 any](seq iter.Seq[T1], fn func(int, T1) T2) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		i := 0
+		for e := range seq {
+			if !yield(fn(i, e)) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// PartitionSeq returns two slices where the first slice contains elements for
+// which the predicate returned true and the second slice contains elements for
+// which it returned false.
+func PartitionSeq[T any](seq iter.Seq[T], fn func(T) bool) ([]T, []T) {
+	trueList := make([]T, 0)
+	falseList := make([]T, 0)
+	for e := range seq {
+		if fn(e) {
+			trueList = append(trueList, e)
+		} else {
+			falseList = append(falseList, e)
+		}
+	}
+	return trueList, falseList
+}
+
+// ReduceSeq accumulates the values starting with the first element and
+// applying the operation from left to right to the current accumulator value
+// and each element. The second return value is false if the sequence was empty.
+func ReduceSeq[T any](seq iter.Seq[T], fn func(T, T) T) (T, bool) {
+	return ReduceIndexedSeq(seq, func(_ int, acc, e T) T { return fn(acc, e) })
+}
+
+// ReduceIndexedSeq accumulates the values starting with the first element and
+// applying the operation from left to right to the current accumulator value
+// and each element. The function also receives the index of the element.
+// The second return value is false if the sequence was empty.
+func ReduceIndexedSeq[T any](seq iter.Seq[T], fn func(int, T, T) T) (T, bool) {
+	var acc T
+	i := 0
+	for e := range seq {
+		if i == 0 {
+			acc = e
+		} else {
+			acc = fn(i, acc, e)
+		}
+		i++
+	}
+	return acc, i > 0
+}
+
+// ReversedSeq returns a sequence with the elements in reverse order.
+// The whole upstream sequence is consumed before the first element is yielded.
+func ReversedSeq[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := Collect(seq)
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
+
+// TakeSeq returns a sequence of the first n elements from the given sequence.
+// No more than n elements are pulled from upstream.
+func TakeSeq[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for e := range seq {
+			if !yield(e) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// TakeLastSeq returns a sequence of the last n elements from the given
+// sequence. The whole upstream sequence is consumed before the first element
+// is yielded, buffering at most n elements.
+func TakeLastSeq[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		// grown as elements arrive so that a large n does not allocate up front
+		var buf []T
+		head := 0
+		for e := range seq {
+			if len(buf) < n {
+				buf = append(buf, e)
+				continue
+			}
+			buf[head] = e
+			head = (head + 1) % n
+		}
+		for i := range buf {
+			if !yield(buf[(head+i)%len(buf)]) {
+				return
+			}
+		}
+	}
+}
+
+// TakeLastWhileSeq returns a sequence containing the last elements satisfying
+// the given predicate. The whole upstream sequence is consumed before the
+// first element is yielded.
+func TakeLastWhileSeq[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var tail []T
+		for e := range seq {
+			if fn(e) {
+				tail = append(tail, e)
+			} else {
+				tail = tail[:0]
+			}
+		}
+		for _, e := range tail {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhileSeq returns a sequence containing the first elements satisfying
+// the given predicate. Stops pulling from upstream at the first element that
+// does not satisfy it.
+func TakeWhileSeq[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range seq {
+			if !fn(e) || !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// TransformMapSeq applies the given function to each key, value in the
+// sequence and yields the transformed key, value. If the last bool return
+// value from the callback function is false, the entry is dropped
+func TransformMapSeq[K1, V1, K2, V2 any](
+	seq iter.Seq2[K1, V1],
+	fn func(K1, V1) (K2, V2, bool),
+) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq {
+			newK, newV, include := fn(k, v)
+			if include && !yield(newK, newV) {
+				return
+			}
+		}
+	}
+}
+
+// UnzipSeq returns two slices, where the first slice is built from the first
+// values of each pair from the input sequence, and the second slice is built
+// from the second values of each pair
+func UnzipSeq[T1, T2 any](seq iter.Seq[*Pair[T1, T2]]) ([]T1, []T2) {
+	s1 := make([]T1, 0)
+	s2 := make([]T2, 0)
+	for p := range seq {
+		s1 = append(s1, p.Fst)
+		s2 = append(s2, p.Snd)
+	}
+	return s1, s2
+}
+
+// WindowedSeq returns a sequence of sliding windows into the given sequence
+// of the given size, and with the given step. Each window is a new slice, so
+// it may be retained by the caller. Like Windowed, it panics if size or step
+// is not positive.
+func WindowedSeq[T any](seq iter.Seq[T], size, step int) iter.Seq[[]T] {
+	mustCheck(checkWindow(size, step))
+	return func(yield func([]T) bool) {
+		// buf holds the elements from the start of the current window
+		var buf []T
+		skip := 0
+		for e := range seq {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			buf = append(buf, e)
+			if len(buf) < size {
+				continue
+			}
+			w := make([]T, size)
+			copy(w, buf)
+			if !yield(w) {
+				return
+			}
+			if step < len(buf) {
+				buf = append(buf[:0], buf[step:]...)
+			} else {
+				skip = step - len(buf)
+				buf = buf[:0]
+			}
+		}
+		// trailing partial windows
+		for len(buf) > 0 {
+			w := make([]T, len(buf))
+			copy(w, buf)
+			if !yield(w) {
+				return
+			}
+			if step >= len(buf) {
+				return
+			}
+			buf = buf[step:]
+		}
+	}
+}
+
+// ZipSeq returns a sequence of pairs from the elements of both sequences in
+// the same position. The returned sequence ends with the shortest input
+// sequence
+func ZipSeq[T1, T2 any](seq1 iter.Seq[T1], seq2 iter.Seq[T2]) iter.Seq[*Pair[T1, T2]] {
+	return func(yield func(*Pair[T1, T2]) bool) {
+		next, stop := iter.Pull(seq2)
+		defer stop()
+		for e1 := range seq1 {
+			e2, ok := next()
+			if !ok {
+				return
+			}
+			if !yield(&Pair[T1, T2]{Fst: e1, Snd: e2}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package fun
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// counted returns a sequence over the given slice along with a pointer to the
+// number of elements pulled from it so far
+func counted[T any](s []T) (iter.Seq[T], *int) {
+	n := 0
+	return func(yield func(T) bool) {
+		for _, e := range s {
+			n++
+			if !yield(e) {
+				return
+			}
+		}
+	}, &n
+}
+
+func TestSeqMatchesEager(t *testing.T) {
+	inputs := [][]int{
+		{},
+		{1},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		{5, 5, 1, 2, 2, 8, 3, 3, 3, 9, 4},
+	}
+	isEven := func(i int) bool { return i%2 == 0 }
+	lessThan5 := func(i int) bool { return i < 5 }
+	greaterThan3 := func(i int) bool { return i > 3 }
+	tests := []struct {
+		name  string
+		eager func([]int) any
+		lazy  func([]int) any
+	}{
+		{"Filter",
+			func(s []int) any { return Filter(s, isEven) },
+			func(s []int) any { return Collect(FilterSeq(AsSeq(s), isEven)) },
+		},
+		{"FilterIndexed",
+			func(s []int) any {
+				return FilterIndexed(s, func(i, v int) bool { return i%3 == v%3 })
+			},
+			func(s []int) any {
+				return Collect(FilterIndexedSeq(AsSeq(s), func(i, v int) bool {
+					return i%3 == v%3
+				}))
+			},
+		},
+		{"FilterMap",
+			func(s []int) any {
+				return FilterMap(s, func(i int) (string, bool) {
+					return fmt.Sprint(i), isEven(i)
+				})
+			},
+			func(s []int) any {
+				return Collect(FilterMapSeq(AsSeq(s), func(i int) (string, bool) {
+					return fmt.Sprint(i), isEven(i)
+				}))
+			},
+		},
+		{"Map",
+			func(s []int) any { return Map(s, func(i int) int { return i * i }) },
+			func(s []int) any {
+				return Collect(MapSeq(AsSeq(s), func(i int) int { return i * i }))
+			},
+		},
+		{"MapIndexed",
+			func(s []int) any {
+				return MapIndexed(s, func(i, v int) int { return i * v })
+			},
+			func(s []int) any {
+				return Collect(MapIndexedSeq(AsSeq(s), func(i, v int) int { return i * v }))
+			},
+		},
+		{"FlatMap",
+			func(s []int) any {
+				return Collect(AsSeq(FlatMap(s, func(i int) []int { return []int{i, i * i} })))
+			},
+			func(s []int) any {
+				return Collect(FlatMapSeq(AsSeq(s), func(i int) []int { return []int{i, i * i} }))
+			},
+		},
+		{"FlatMapIndexed",
+			func(s []int) any {
+				return Collect(AsSeq(FlatMapIndexed(s, func(i, v int) []int { return []int{i, v} })))
+			},
+			func(s []int) any {
+				return Collect(FlatMapIndexedSeq(AsSeq(s), func(i, v int) []int { return []int{i, v} }))
+			},
+		},
+		{"Chunked",
+			func(s []int) any { return Chunked(s, 3) },
+			func(s []int) any { return Collect(ChunkedSeq(AsSeq(s), 3)) },
+		},
+		{"ChunkedBy",
+			func(s []int) any { return ChunkedBy(s, func(a, b int) bool { return a <= b }) },
+			func(s []int) any {
+				return Collect(ChunkedBySeq(AsSeq(s), func(a, b int) bool { return a <= b }))
+			},
+		},
+		{"Distinct",
+			func(s []int) any { return Distinct(s) },
+			func(s []int) any { return Collect(DistinctSeq(AsSeq(s))) },
+		},
+		{"DistinctBy",
+			func(s []int) any { return DistinctBy(s, func(i int) int { return i % 3 }) },
+			func(s []int) any {
+				return Collect(DistinctBySeq(AsSeq(s), func(i int) int { return i % 3 }))
+			},
+		},
+		{"Drop",
+			func(s []int) any { return Collect(AsSeq(Drop(s, 3))) },
+			func(s []int) any { return Collect(DropSeq(AsSeq(s), 3)) },
+		},
+		{"DropLast",
+			func(s []int) any { return Collect(AsSeq(DropLast(s, 3))) },
+			func(s []int) any { return Collect(DropLastSeq(AsSeq(s), 3)) },
+		},
+		{"DropWhile",
+			func(s []int) any { return Collect(AsSeq(DropWhile(s, lessThan5))) },
+			func(s []int) any { return Collect(DropWhileSeq(AsSeq(s), lessThan5)) },
+		},
+		{"DropLastWhile",
+			func(s []int) any { return Collect(AsSeq(DropLastWhile(s, greaterThan3))) },
+			func(s []int) any { return Collect(DropLastWhileSeq(AsSeq(s), greaterThan3)) },
+		},
+		{"Take",
+			func(s []int) any { return Collect(AsSeq(Take(s, 3))) },
+			func(s []int) any { return Collect(TakeSeq(AsSeq(s), 3)) },
+		},
+		{"TakeLast",
+			func(s []int) any { return Collect(AsSeq(TakeLast(s, 3))) },
+			func(s []int) any { return Collect(TakeLastSeq(AsSeq(s), 3)) },
+		},
+		{"TakeWhile",
+			func(s []int) any { return Collect(AsSeq(TakeWhile(s, lessThan5))) },
+			func(s []int) any { return Collect(TakeWhileSeq(AsSeq(s), lessThan5)) },
+		},
+		{"TakeLastWhile",
+			func(s []int) any { return Collect(AsSeq(TakeLastWhile(s, greaterThan3))) },
+			func(s []int) any { return Collect(TakeLastWhileSeq(AsSeq(s), greaterThan3)) },
+		},
+		// sizes and counts near math.MaxInt must not be allocated up front
+		{"Chunked MaxInt",
+			func(s []int) any { return Chunked(s, math.MaxInt) },
+			func(s []int) any { return Collect(ChunkedSeq(AsSeq(s), math.MaxInt)) },
+		},
+		{"DropLast MaxInt",
+			func(s []int) any { return Collect(AsSeq(DropLast(s, math.MaxInt))) },
+			func(s []int) any { return Collect(DropLastSeq(AsSeq(s), math.MaxInt)) },
+		},
+		{"TakeLast MaxInt",
+			func(s []int) any { return Collect(AsSeq(TakeLast(s, math.MaxInt))) },
+			func(s []int) any { return Collect(TakeLastSeq(AsSeq(s), math.MaxInt)) },
+		},
+		{"Windowed MaxInt",
+			func(s []int) any { return Windowed(s, math.MaxInt, math.MaxInt) },
+			func(s []int) any { return Collect(WindowedSeq(AsSeq(s), math.MaxInt, math.MaxInt)) },
+		},
+		{"Reversed",
+			func(s []int) any { return Reversed(s) },
+			func(s []int) any { return Collect(ReversedSeq(AsSeq(s))) },
+		},
+		{"Windowed 5,1",
+			func(s []int) any { return Windowed(s, 5, 1) },
+			func(s []int) any { return Collect(WindowedSeq(AsSeq(s), 5, 1)) },
+		},
+		{"Windowed 5,3",
+			func(s []int) any { return Windowed(s, 5, 3) },
+			func(s []int) any { return Collect(WindowedSeq(AsSeq(s), 5, 3)) },
+		},
+		{"Windowed 3,4",
+			func(s []int) any { return Windowed(s, 3, 4) },
+			func(s []int) any { return Collect(WindowedSeq(AsSeq(s), 3, 4)) },
+		},
+		{"Windowed 2,2",
+			func(s []int) any { return Windowed(s, 2, 2) },
+			func(s []int) any { return Collect(WindowedSeq(AsSeq(s), 2, 2)) },
+		},
+		{"Partition",
+			func(s []int) any { a, b := Partition(s, isEven); return []any{a, b} },
+			func(s []int) any { a, b := PartitionSeq(AsSeq(s), isEven); return []any{a, b} },
+		},
+		{"All",
+			func(s []int) any { return All(s, lessThan5) },
+			func(s []int) any { return AllSeq(AsSeq(s), lessThan5) },
+		},
+		{"Any",
+			func(s []int) any { return Any(s, greaterThan3) },
+			func(s []int) any { return AnySeq(AsSeq(s), greaterThan3) },
+		},
+		{"Fold",
+			func(s []int) any { return Fold(s, 1, func(acc, v int) int { return acc*2 + v }) },
+			func(s []int) any {
+				return FoldSeq(AsSeq(s), 1, func(acc, v int) int { return acc*2 + v })
+			},
+		},
+		{"FoldIndexed",
+			func(s []int) any {
+				return FoldIndexed(s, 0, func(i, acc, v int) int { return acc + i*v })
+			},
+			func(s []int) any {
+				return FoldIndexedSeq(AsSeq(s), 0, func(i, acc, v int) int { return acc + i*v })
+			},
+		},
+		{"Associate",
+			func(s []int) any {
+				return Associate(s, func(i int) (int, int) { return i % 4, i })
+			},
+			func(s []int) any {
+				return AssociateSeq(AsSeq(s), func(i int) (int, int) { return i % 4, i })
+			},
+		},
+		{"GroupBy",
+			func(s []int) any {
+				return GroupBy(s, func(i int) (int, int) { return i % 4, i })
+			},
+			func(s []int) any {
+				return GroupBySeq(AsSeq(s), func(i int) (int, int) { return i % 4, i })
+			},
+		},
+		{"Zip",
+			func(s []int) any { return Zip(s, Reversed(s)[1:min(len(s), 4)]) },
+			func(s []int) any {
+				return Collect(ZipSeq(AsSeq(s), AsSeq(Reversed(s)[1:min(len(s), 4)])))
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, in := range inputs {
+			if tt.name == "Zip" && len(in) == 0 {
+				continue
+			}
+			t.Run(fmt.Sprintf("%s %v", tt.name, in), func(t *testing.T) {
+				want := tt.eager(in)
+				got := tt.lazy(in)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%sSeq() = %v, want %v", tt.name, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestReduceSeq(t *testing.T) {
+	s := []string{"a", "b", "c", "d"}
+	got, ok := ReduceIndexedSeq(AsSeq(s), func(i int, acc, v string) string {
+		return fmt.Sprintf("%s%s%d", acc, v, i)
+	})
+	want := ReduceIndexed(s, func(i int, acc, v string) string {
+		return fmt.Sprintf("%s%s%d", acc, v, i)
+	})
+	if !ok || got != want {
+		t.Errorf("ReduceIndexedSeq() = %v, %v, want %v, true", got, ok, want)
+	}
+	_, ok = ReduceSeq(AsSeq([]string{}), func(a, b string) string { return a + b })
+	if ok {
+		t.Errorf("ReduceSeq() on empty sequence returned ok")
+	}
+}
+
+func TestItemsSeq(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	got := ToMap(TransformMapSeq(ItemsSeq(m), func(k string, v int) (int, string, bool) {
+		return v, k, v != 2
+	}))
+	want := map[int]string{1: "a", 3: "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TransformMapSeq() = %v, want %v", got, want)
+	}
+	sum := FoldItemsSeq(ItemsSeq(m), 0, func(acc int, _ string, v int) int { return acc + v })
+	if sum != 6 {
+		t.Errorf("FoldItemsSeq() = %v, want %v", sum, 6)
+	}
+}
+
+func TestUnzipSeq(t *testing.T) {
+	ps := []*Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}
+	got1, got2 := UnzipSeq(AsSeq(ps))
+	want1, want2 := Unzip(ps)
+	if !reflect.DeepEqual(got1, want1) || !reflect.DeepEqual(got2, want2) {
+		t.Errorf("UnzipSeq() = %v, %v, want %v, %v", got1, got2, want1, want2)
+	}
+}
+
+func TestSeqShortCircuits(t *testing.T) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+	tests := []struct {
+		name   string
+		run    func(iter.Seq[int])
+		pulled int
+	}{
+		{"Take",
+			func(seq iter.Seq[int]) { Collect(TakeSeq(seq, 10)) },
+			10,
+		},
+		{"Filter Map Take",
+			func(seq iter.Seq[int]) {
+				Collect(TakeSeq(MapSeq(FilterSeq(seq, func(i int) bool {
+					return i%2 == 0
+				}), func(i int) int { return i * i }), 10))
+			},
+			19,
+		},
+		{"TakeWhile",
+			func(seq iter.Seq[int]) {
+				Collect(TakeWhileSeq(seq, func(i int) bool { return i < 5 }))
+			},
+			6,
+		},
+		{"Any",
+			func(seq iter.Seq[int]) { AnySeq(seq, func(i int) bool { return i == 3 }) },
+			4,
+		},
+		{"All",
+			func(seq iter.Seq[int]) { AllSeq(seq, func(i int) bool { return i < 7 }) },
+			8,
+		},
+		{"Chunked Take",
+			func(seq iter.Seq[int]) { Collect(TakeSeq(ChunkedSeq(seq, 4), 2)) },
+			8,
+		},
+		{"Windowed Take",
+			func(seq iter.Seq[int]) { Collect(TakeSeq(WindowedSeq(seq, 5, 1), 2)) },
+			6,
+		},
+		{"Zip Take",
+			func(seq iter.Seq[int]) {
+				Collect(TakeSeq(ZipSeq(seq, AsSeq(input)), 3))
+			},
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq, n := counted(input)
+			tt.run(seq)
+			if *n != tt.pulled {
+				t.Errorf("%s pulled %d elements, want %d", tt.name, *n, tt.pulled)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package fun
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Set represents an unordered collection of distinct elements.
+// A nil Set can be read from, but must be created using NewSet, SetOf or make
+// before adding elements.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a set containing the given elements
+func NewSet[T comparable](elems ...T) Set[T] {
+	return SetOf(elems)
+}
+
+// SetOf returns a set containing the elements of the given slice
+func SetOf[T comparable](s []T) Set[T] {
+	ret := make(Set[T], len(s))
+	for _, e := range s {
+		ret[e] = struct{}{}
+	}
+	return ret
+}
+
+// Add adds the given elements to the set
+func (s Set[T]) Add(elems ...T) {
+	for _, e := range elems {
+		s[e] = struct{}{}
+	}
+}
+
+// Remove removes the given elements from the set, if present
+func (s Set[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		delete(s, e)
+	}
+}
+
+// Contains returns true if the given element is in the set
+func (s Set[T]) Contains(e T) bool {
+	_, ok := s[e]
+	return ok
+}
+
+// Len returns the number of elements in the set
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a new set with the same elements
+func (s Set[T]) Clone() Set[T] {
+	ret := make(Set[T], len(s))
+	for e := range s {
+		ret[e] = struct{}{}
+	}
+	return ret
+}
+
+// Union returns a new set with the elements that are in either set
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	ret := s.Clone()
+	for e := range o {
+		ret[e] = struct{}{}
+	}
+	return ret
+}
+
+// Intersection returns a new set with the elements that are in both sets
+func (s Set[T]) Intersection(o Set[T]) Set[T] {
+	small, large := s, o
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	ret := make(Set[T])
+	for e := range small {
+		if large.Contains(e) {
+			ret[e] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// Difference returns a new set with the elements of this set that are not in
+// the other set
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	ret := make(Set[T])
+	for e := range s {
+		if !o.Contains(e) {
+			ret[e] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// SymmetricDifference returns a new set with the elements that are in exactly
+// one of the two sets
+func (s Set[T]) SymmetricDifference(o Set[T]) Set[T] {
+	ret := s.Difference(o)
+	for e := range o {
+		if !s.Contains(e) {
+			ret[e] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// IsSubset returns true if every element of this set is in the other set
+func (s Set[T]) IsSubset(o Set[T]) bool {
+	if len(s) > len(o) {
+		return false
+	}
+	for e := range s {
+		if !o.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if every element of the other set is in this set
+func (s Set[T]) IsSuperset(o Set[T]) bool {
+	return o.IsSubset(s)
+}
+
+// Equal returns true if both sets contain the same elements
+func (s Set[T]) Equal(o Set[T]) bool {
+	return len(s) == len(o) && s.IsSubset(o)
+}
+
+// ToSlice returns the elements of the set as a slice, in no particular order.
+// Use SortedSlice or SortedSliceFunc for a deterministic order.
+func (s Set[T]) ToSlice() []T {
+	ret := make([]T, 0, len(s))
+	for e := range s {
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+func (s Set[T]) String() string {
+	parts := make([]string, 0, len(s))
+	for e := range s {
+		parts = append(parts, fmt.Sprint(e))
+	}
+	slices.Sort(parts)
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// MarshalJSON encodes the set as a JSON array. Elements are ordered by their
+// encoded form, so the output is deterministic.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	encoded := make([][]byte, 0, len(s))
+	for e := range s {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, b)
+	}
+	slices.SortFunc(encoded, bytes.Compare)
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	buf.Write(bytes.Join(encoded, []byte{','}))
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its contents.
+// Duplicate array elements are collapsed.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	*s = SetOf(elems)
+	return nil
+}
+
+// SortedSlice returns the elements of the given set as a slice in ascending
+// order
+func SortedSlice[T cmp.Ordered](s Set[T]) []T {
+	ret := s.ToSlice()
+	slices.Sort(ret)
+	return ret
+}
+
+// SortedSliceFunc returns the elements of the given set as a slice, ordered by
+// the given comparison function
+func SortedSliceFunc[T comparable](s Set[T], cmp func(T, T) int) []T {
+	ret := s.ToSlice()
+	slices.SortFunc(ret, cmp)
+	return ret
+}
+
+// DistinctSet returns a set containing the distinct elements of the given slice
+func DistinctSet[T comparable](s []T) Set[T] {
+	return SetOf(s)
+}
+
+// DistinctBySet returns a set containing the distinct keys returned by the
+// given selector function applied to the elements of the given slice
+func DistinctBySet[T any, K comparable](s []T, fn func(T) K) Set[K] {
+	ret := make(Set[K])
+	for _, e := range s {
+		ret[fn(e)] = struct{}{}
+	}
+	return ret
+}
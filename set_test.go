@@ -0,0 +1,131 @@
+package fun
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetBasics(t *testing.T) {
+	s := NewSet(1, 2, 3, 2)
+	if s.Len() != 3 {
+		t.Errorf("NewSet().Len() = %d, want 3", s.Len())
+	}
+	s.Add(4, 1)
+	s.Remove(2, 7)
+	if got := SortedSlice(s); !reflect.DeepEqual(got, []int{1, 3, 4}) {
+		t.Errorf("SortedSlice() = %v, want [1 3 4]", got)
+	}
+	if !s.Contains(3) || s.Contains(2) {
+		t.Errorf("Contains() mismatch for %v", s)
+	}
+	if got := s.String(); got != "{1, 3, 4}" {
+		t.Errorf("String() = %q, want %q", got, "{1, 3, 4}")
+	}
+
+	var empty Set[int]
+	if empty.Len() != 0 || empty.Contains(1) || !empty.IsSubset(s) {
+		t.Errorf("nil Set is not readable as empty")
+	}
+
+	c := s.Clone()
+	c.Add(10)
+	if s.Contains(10) {
+		t.Errorf("Clone() shares storage with the original")
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference reversed", b.Difference(a), []int{5}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 2, 5}},
+		{"Intersection with empty", a.Intersection(NewSet[int]()), []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SortedSlice(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+	if got := SortedSlice(a); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+		t.Errorf("set algebra modified its receiver: %v", got)
+	}
+}
+
+func TestSetRelations(t *testing.T) {
+	a := NewSet("a", "b")
+	b := NewSet("a", "b", "c")
+	if !a.IsSubset(b) || b.IsSubset(a) {
+		t.Errorf("IsSubset() mismatch for %v and %v", a, b)
+	}
+	if !b.IsSuperset(a) || a.IsSuperset(b) {
+		t.Errorf("IsSuperset() mismatch for %v and %v", a, b)
+	}
+	if !a.IsSubset(a) || !a.Equal(NewSet("b", "a")) || a.Equal(b) {
+		t.Errorf("Equal() mismatch for %v and %v", a, b)
+	}
+}
+
+func TestSetSlices(t *testing.T) {
+	s := SetOf([]string{"b", "C", "a"})
+	if got := SortedSlice(s); !reflect.DeepEqual(got, []string{"C", "a", "b"}) {
+		t.Errorf("SortedSlice() = %v", got)
+	}
+	byLower := func(x, y string) int {
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	}
+	if got := SortedSliceFunc(s, byLower); !reflect.DeepEqual(got, []string{"a", "b", "C"}) {
+		t.Errorf("SortedSliceFunc() = %v", got)
+	}
+	if got := s.ToSlice(); len(got) != 3 || !SetOf(got).Equal(s) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	s := NewSet(3, 1, 2)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("json.Marshal() = %s, want [1,2,3]", data)
+	}
+	data, err = json.Marshal(map[string]Set[string]{"tags": NewSet[string]()})
+	if err != nil || string(data) != `{"tags":[]}` {
+		t.Errorf("json.Marshal() = %s, %v, want {\"tags\":[]}", data, err)
+	}
+
+	var got Set[int]
+	if err := json.Unmarshal([]byte("[2, 2, 5]"), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !got.Equal(NewSet(2, 5)) {
+		t.Errorf("json.Unmarshal() = %v, want {2, 5}", got)
+	}
+	if err := json.Unmarshal([]byte(`{"a": 1}`), &got); err == nil {
+		t.Errorf("json.Unmarshal() of an object did not fail")
+	}
+}
+
+func TestDistinctSet(t *testing.T) {
+	got := DistinctSet([]int{1, 1, 2, 3, 3, 4})
+	if !got.Equal(NewSet(1, 2, 3, 4)) {
+		t.Errorf("DistinctSet() = %v", got)
+	}
+	keys := DistinctBySet([]string{"a", "A", "b", "B"}, strings.ToLower)
+	if !keys.Equal(NewSet("a", "b")) {
+		t.Errorf("DistinctBySet() = %v", keys)
+	}
+}
@@ -34,7 +34,9 @@ func AppendToGroup[M ~map[K][]V, K comparable, V any](m M, k K, v V) {
 }
 
 // Associate returns a map containing key-value pairs returned by the given
-// function applied to the elements of the given slice
+// function applied to the elements of the given slice.
+// If several elements produce the same key, the last one's value is kept; see
+// AssociateResolve and AssociateUnique.
 func Associate[T, V any, K comparable](s []T, fn func(T) (K, V)) map[K]V {
 	ret := make(map[K]V)
 	for _, e := range s {
@@ -44,9 +46,25 @@ func Associate[T, V any, K comparable](s []T, fn func(T) (K, V)) map[K]V {
 	return ret
 }
 
+// AssociateBy returns a map from the key returned by the given selector
+// function for each element of the given slice to the element.
+// If several elements have the same key, the last one is kept.
+func AssociateBy[T any, K comparable](s []T, fn func(T) K) map[K]T {
+	return Associate(s, func(e T) (K, T) { return fn(e), e })
+}
+
+// AssociateWith returns a map from each element of the given slice to the
+// value returned by the given function for it
+func AssociateWith[K comparable, V any](s []K, fn func(K) V) map[K]V {
+	return Associate(s, func(k K) (K, V) { return k, fn(k) })
+}
+
 // Chunked splits the slice into a slice of slices, each not exceeding given size
-// The last slice might have fewer elements than the given size
+// The last slice might have fewer elements than the given size.
+// It panics with an error wrapping ErrInvalidChunkSize if chunkSize is not
+// positive; see ChunkedChecked.
 func Chunked[T any](s []T, chunkSize int) [][]T {
+	mustCheck(checkSize(chunkSize))
 	sz := len(s)
 	ret := make([][]T, 0, sz/chunkSize+2)
 	var sub []T
@@ -55,7 +73,7 @@ func Chunked[T any](s []T, chunkSize int) [][]T {
 			if len(sub) > 0 {
 				ret = append(ret, sub)
 			}
-			sub = make([]T, 0, chunkSize)
+			sub = make([]T, 0, min(chunkSize, sz-i))
 		}
 		sub = append(sub, s[i])
 	}
@@ -93,14 +111,13 @@ func ChunkedBy[T any](s []T, fn func(T, T) bool) [][]T {
 // Distinct returns a slice containing only distinct elements from the given slice
 // Elements will retain their original order.
 func Distinct[T comparable](s []T) []T {
-	m := make(map[T]bool)
+	seen := make(Set[T])
 	ret := make([]T, 0)
 	for _, e := range s {
-		_, ok := m[e]
-		if ok {
+		if seen.Contains(e) {
 			continue
 		}
-		m[e] = true
+		seen.Add(e)
 		ret = append(ret, e)
 	}
 	return ret
@@ -110,64 +127,79 @@ func Distinct[T comparable](s []T) []T {
 // given slice as distinguished by the given selector function
 // Elements will retain their original order.
 func DistinctBy[T any, K comparable](s []T, fn func(T) K) []T {
-	m := make(map[K]bool)
+	seen := make(Set[K])
 	ret := make([]T, 0)
 	for _, e := range s {
 		k := fn(e)
-		_, ok := m[k]
-		if ok {
+		if seen.Contains(k) {
 			continue
 		}
-		m[k] = true
+		seen.Add(k)
 		ret = append(ret, e)
 	}
 	return ret
 }
 
-// Drop returns a slice containing all elements except the first n
+// Drop returns a slice containing all elements except the first n.
+// A negative n is treated as zero; see DropChecked.
+// The result is a view into the given slice; see DropCloned.
 func Drop[T any](s []T, n int) []T {
-	if n >= len(s) {
-		return make([]T, 0)
-	}
-	return s[n:]
+	n = min(max(n, 0), len(s))
+	return view(s, n, len(s))
 }
 
-// DropLast returns a slice containing all elements except the last n
+// DropCloned is like Drop, but returns a copy
+func DropCloned[T any](s []T, n int) []T {
+	return cloned(Drop(s, n))
+}
+
+// DropLast returns a slice containing all elements except the last n.
+// A negative n is treated as zero; see DropLastChecked.
+// The result is a view into the given slice; see DropLastCloned.
 func DropLast[T any](s []T, n int) []T {
-	if n >= len(s) {
-		return make([]T, 0)
-	}
-	return s[:len(s)-n]
+	n = min(max(n, 0), len(s))
+	return view(s, 0, len(s)-n)
+}
+
+// DropLastCloned is like DropLast, but returns a copy
+func DropLastCloned[T any](s []T, n int) []T {
+	return cloned(DropLast(s, n))
 }
 
 // DropLastWhile returns a slice containing all elements except the last elements
-// that satisfy the given predicate
+// that satisfy the given predicate.
+// The result is a view into the given slice; see DropLastWhileCloned.
 func DropLastWhile[T any](s []T, fn func(T) bool) []T {
-	if len(s) == 0 {
-		return s
-	}
 	i := len(s) - 1
 	for ; i >= 0; i-- {
 		if !fn(s[i]) {
 			break
 		}
 	}
-	return s[:i+1]
+	return view(s, 0, i+1)
+}
+
+// DropLastWhileCloned is like DropLastWhile, but returns a copy
+func DropLastWhileCloned[T any](s []T, fn func(T) bool) []T {
+	return cloned(DropLastWhile(s, fn))
 }
 
 // DropWhile returns a slice containing all elements except the first elements
-// that satisfy the given predicate
+// that satisfy the given predicate.
+// The result is a view into the given slice; see DropWhileCloned.
 func DropWhile[T any](s []T, fn func(T) bool) []T {
-	if len(s) == 0 {
-		return s
-	}
 	i := 0
 	for ; i < len(s); i++ {
 		if !fn(s[i]) {
 			break
 		}
 	}
-	return s[i:]
+	return view(s, i, len(s))
+}
+
+// DropWhileCloned is like DropWhile, but returns a copy
+func DropWhileCloned[T any](s []T, fn func(T) bool) []T {
+	return cloned(DropWhile(s, fn))
 }
 
 // Filter returns the slice obtained after retaining only those elements
@@ -221,7 +253,7 @@ func FilterMap[T1, T2 any](
 // This function applies fn to every element in s,
 // and combines the results into a single, "flattened" slice of T2 elements.
 func FlatMap[T1, T2 any](s []T1, fn func(T1) []T2) []T2 {
-	var ret []T2
+	ret := make([]T2, 0)
 	for _, e := range s {
 		ret = append(ret, fn(e)...)
 	}
@@ -233,7 +265,7 @@ func FlatMap[T1, T2 any](s []T1, fn func(T1) []T2) []T2 {
 // returns a slice of T2 elements.
 // This function applies fn to every element in s, and combines the results into a single, "flattened" slice of T2 elements.
 func FlatMapIndexed[T1, T2 any](s []T1, fn func(int, T1) []T2) []T2 {
-	var ret []T2
+	ret := make([]T2, 0)
 	for i, e := range s {
 		ret = append(ret, fn(i, e)...)
 	}
@@ -399,57 +431,76 @@ func Reversed[T any](s []T) []T {
 
 // Take returns the slice obtained after taking the first n elements from the
 // given slice.
-// If n is greater than the length of the slice, returns the entire slice
+// If n is greater than the length of the slice, returns the entire slice.
+// A negative n is treated as zero; see TakeChecked.
+// The result is a view into the given slice; see TakeCloned.
 func Take[T any](s []T, n int) []T {
-	if len(s) <= n {
-		return s
-	}
-	return s[:n]
+	n = min(max(n, 0), len(s))
+	return view(s, 0, n)
+}
+
+// TakeCloned is like Take, but returns a copy
+func TakeCloned[T any](s []T, n int) []T {
+	return cloned(Take(s, n))
 }
 
 // TakeLast returns the slice obtained after taking the last n elements from the
 // given slice.
+// A negative n is treated as zero; see TakeLastChecked.
+// The result is a view into the given slice; see TakeLastCloned.
 func TakeLast[T any](s []T, n int) []T {
-	if len(s) <= n {
-		return s
-	}
-	return s[len(s)-n:]
+	n = min(max(n, 0), len(s))
+	return view(s, len(s)-n, len(s))
+}
+
+// TakeLastCloned is like TakeLast, but returns a copy
+func TakeLastCloned[T any](s []T, n int) []T {
+	return cloned(TakeLast(s, n))
 }
 
 // TakeLastWhile returns a slice containing the last elements satisfying the given
-// predicate
+// predicate.
+// The result is a view into the given slice; see TakeLastWhileCloned.
 func TakeLastWhile[T any](s []T, fn func(T) bool) []T {
-	if len(s) == 0 {
-		return s
-	}
 	i := len(s) - 1
 	for ; i >= 0; i-- {
 		if !fn(s[i]) {
 			break
 		}
 	}
-	return s[i+1:]
+	return view(s, i+1, len(s))
+}
+
+// TakeLastWhileCloned is like TakeLastWhile, but returns a copy
+func TakeLastWhileCloned[T any](s []T, fn func(T) bool) []T {
+	return cloned(TakeLastWhile(s, fn))
 }
 
 // TakeWhile returns a list containing the first elements satisfying the
-// given predicate
+// given predicate.
+// The result is a view into the given slice; see TakeWhileCloned.
 func TakeWhile[T any](s []T, fn func(T) bool) []T {
-	if len(s) == 0 {
-		return s
-	}
 	i := 0
 	for ; i < len(s); i++ {
 		if !fn(s[i]) {
 			break
 		}
 	}
-	return s[:i]
+	return view(s, 0, i)
+}
+
+// TakeWhileCloned is like TakeWhile, but returns a copy
+func TakeWhileCloned[T any](s []T, fn func(T) bool) []T {
+	return cloned(TakeWhile(s, fn))
 }
 
 // TransformMap applies the given function to each key, value in the map,
 // and returns a new map of the same type after transforming the keys
 // and values depending on the callback functions return values. If the last
-// bool return value from the callback function is false, the entry is dropped
+// bool return value from the callback function is false, the entry is dropped.
+// If the callback returns the same key for several entries, which value is
+// kept is unspecified; see TransformMapWith, TransformMapToGroup and
+// TransformMapUnique.
 func TransformMap[M ~map[K]V, K comparable, V any](
 	m M,
 	fn func(k K, v V) (K, V, bool),
@@ -479,8 +530,11 @@ func Unzip[T1 any, T2 any](ps []*Pair[T1, T2]) ([]T1, []T2) {
 }
 
 // Windowed returns a slice of sliding windows into the given slice of the
-// given size, and with the given step
+// given size, and with the given step.
+// It panics with an error wrapping ErrInvalidChunkSize or ErrInvalidStep if
+// size or step is not positive; see WindowedChecked.
 func Windowed[T any](s []T, size, step int) [][]T {
+	mustCheck(checkWindow(size, step))
 	ret := make([][]T, 0)
 	sz := len(s)
 	if sz == 0 {
@@ -488,24 +542,17 @@ func Windowed[T any](s []T, size, step int) [][]T {
 	}
 	start := 0
 	end := 0
+	// adding at most the remaining length cannot overflow
 	updateEnd := func() {
-		e := start + size
-		if e >= sz {
-			e = sz
-		}
-		end = e
+		end = start + min(size, sz-start)
 	}
 	updateStart := func() {
-		s := start + step
-		if s >= sz {
-			s = sz
-		}
-		start = s
+		start += min(step, sz-start)
 	}
 	updateEnd()
 
 	for {
-		sub := make([]T, 0, end)
+		sub := make([]T, 0, end-start)
 		for i := start; i < end; i++ {
 			sub = append(sub, s[i])
 		}
@@ -548,3 +595,17 @@ type Pair[T1, T2 any] struct {
 func (p Pair[T1, T2]) String() string {
 	return fmt.Sprintf("(%v, %v)", p.Fst, p.Snd)
 }
+
+// view returns s[i:j] with its capacity limited to its length, so appending to
+// it cannot overwrite the elements of s that follow. It is never nil.
+func view[T any](s []T, i, j int) []T {
+	if s == nil {
+		return make([]T, 0)
+	}
+	return s[i:j:j]
+}
+
+// cloned returns a copy of s that is never nil
+func cloned[T any](s []T) []T {
+	return append(make([]T, 0, len(s)), s...)
+}
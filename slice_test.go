@@ -156,6 +156,22 @@ func TestAssociate(t *testing.T) {
 	}
 }
 
+func TestAssociateBy(t *testing.T) {
+	words := []string{"a", "bb", "cc", "ddd"}
+	got := AssociateBy(words, func(w string) int { return len(w) })
+	if want := map[int]string{1: "a", 2: "cc", 3: "ddd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AssociateBy() = %v, want %v", got, want)
+	}
+}
+
+func TestAssociateWith(t *testing.T) {
+	words := []string{"a", "bb", "a"}
+	got := AssociateWith(words, func(w string) int { return len(w) })
+	if want := map[string]int{"a": 1, "bb": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AssociateWith() = %v, want %v", got, want)
+	}
+}
+
 func TestChunked(t *testing.T) {
 	type args struct {
 		s []int
@@ -697,7 +713,7 @@ func TestItems(t *testing.T) {
 		return got[i].Fst < got[j].Fst
 	})
 
-	if got := Items(m); !reflect.DeepEqual(got, want) {
+	if !reflect.DeepEqual(got, want) {
 		t.Errorf("Items() = %v, want %v", got, want)
 	}
 }
@@ -0,0 +1,243 @@
+package fun
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Comparator compares two values, returning a negative number if a sorts
+// before b, a positive number if a sorts after b, and zero otherwise.
+// Comparators can be used with SortWith, SortedWith and slices.SortFunc.
+// Comparators built from selector functions, such as ComparingBy and ThenBy,
+// call the selectors on every comparison, O(n log n) times for a sort. When
+// keys are costly to compute, use SortWithKey, which computes one key per
+// element and orders the keys with a comparator.
+type Comparator[T any] func(a, b T) int
+
+// ComparingBy returns a comparator ordering values by the key returned by the
+// given selector function, in ascending order
+func ComparingBy[T any, K cmp.Ordered](fn func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(fn(a), fn(b))
+	}
+}
+
+// ComparingByDescending returns a comparator ordering values by the key
+// returned by the given selector function, in descending order
+func ComparingByDescending[T any, K cmp.Ordered](fn func(T) K) Comparator[T] {
+	return ComparingBy(fn).Reversed()
+}
+
+// Then returns a comparator that orders values using this comparator, and
+// orders values it considers equal using the given comparator
+func (c Comparator[T]) Then(o Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return o(a, b)
+	}
+}
+
+// Reversed returns a comparator that orders values in the opposite order
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// ThenBy returns a comparator that orders values using the given comparator,
+// and orders values it considers equal by the key returned by the given
+// selector function, in ascending order
+func ThenBy[T any, K cmp.Ordered](c Comparator[T], fn func(T) K) Comparator[T] {
+	return c.Then(ComparingBy(fn))
+}
+
+// ThenByDescending returns a comparator that orders values using the given
+// comparator, and orders values it considers equal by the key returned by the
+// given selector function, in descending order
+func ThenByDescending[T any, K cmp.Ordered](c Comparator[T], fn func(T) K) Comparator[T] {
+	return c.Then(ComparingByDescending(fn))
+}
+
+// NullsFirst returns a comparator for pointers that orders nil before all
+// other values, and orders non-nil pointers by the values they point to using
+// the given comparator
+func NullsFirst[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return c(*a, *b)
+	}
+}
+
+// NullsLast returns a comparator for pointers that orders nil after all other
+// values, and orders non-nil pointers by the values they point to using the
+// given comparator
+func NullsLast[T any](c Comparator[T]) Comparator[*T] {
+	nullsFirst := NullsFirst(c)
+	return func(a, b *T) int {
+		if (a == nil) != (b == nil) {
+			return -nullsFirst(a, b)
+		}
+		return nullsFirst(a, b)
+	}
+}
+
+// SortBy sorts the elements of the slice in place, in ascending order of the
+// key returned by the given selector function. The selector is called once
+// per element.
+func SortBy[T any, K cmp.Ordered](s []T, fn func(T) K) {
+	sortByKey(s, fn, false, false)
+}
+
+// SortByDescending sorts the elements of the slice in place, in descending
+// order of the key returned by the given selector function. The selector is
+// called once per element.
+func SortByDescending[T any, K cmp.Ordered](s []T, fn func(T) K) {
+	sortByKey(s, fn, true, false)
+}
+
+// SortStableBy is like SortBy, but keeps elements with equal keys in their
+// original order
+func SortStableBy[T any, K cmp.Ordered](s []T, fn func(T) K) {
+	sortByKey(s, fn, false, true)
+}
+
+// SortStableByDescending is like SortByDescending, but keeps elements with
+// equal keys in their original order
+func SortStableByDescending[T any, K cmp.Ordered](s []T, fn func(T) K) {
+	sortByKey(s, fn, true, true)
+}
+
+// SortedBy returns a new slice with the elements in ascending order of the
+// key returned by the given selector function. The selector is called once per
+// element.
+func SortedBy[T any, K cmp.Ordered](s []T, fn func(T) K) []T {
+	ret := cloned(s)
+	SortBy(ret, fn)
+	return ret
+}
+
+// SortedByDescending returns a new slice with the elements in descending order
+// of the key returned by the given selector function. The selector is called
+// once per element.
+func SortedByDescending[T any, K cmp.Ordered](s []T, fn func(T) K) []T {
+	ret := cloned(s)
+	SortByDescending(ret, fn)
+	return ret
+}
+
+// SortedStableBy is like SortedBy, but keeps elements with equal keys in their
+// original order
+func SortedStableBy[T any, K cmp.Ordered](s []T, fn func(T) K) []T {
+	ret := cloned(s)
+	SortStableBy(ret, fn)
+	return ret
+}
+
+// SortedStableByDescending is like SortedByDescending, but keeps elements with
+// equal keys in their original order
+func SortedStableByDescending[T any, K cmp.Ordered](s []T, fn func(T) K) []T {
+	ret := cloned(s)
+	SortStableByDescending(ret, fn)
+	return ret
+}
+
+// SortWith sorts the elements of the slice in place, using the given comparator
+func SortWith[T any](s []T, c Comparator[T]) {
+	slices.SortFunc(s, c)
+}
+
+// SortStableWith sorts the elements of the slice in place, using the given
+// comparator and keeping equal elements in their original order
+func SortStableWith[T any](s []T, c Comparator[T]) {
+	slices.SortStableFunc(s, c)
+}
+
+// SortedWith returns a new slice with the elements ordered by the given
+// comparator
+func SortedWith[T any](s []T, c Comparator[T]) []T {
+	ret := cloned(s)
+	SortWith(ret, c)
+	return ret
+}
+
+// SortedStableWith returns a new slice with the elements ordered by the given
+// comparator, keeping equal elements in their original order
+func SortedStableWith[T any](s []T, c Comparator[T]) []T {
+	ret := cloned(s)
+	SortStableWith(ret, c)
+	return ret
+}
+
+// SortWithKey sorts the elements of the slice in place, in the order of the
+// keys returned by the given selector function as given by the comparator. The
+// selector is called once per element, so a key combining several fields can
+// be computed once and compared with a comparator built with ComparingBy and
+// ThenBy.
+func SortWithKey[T, K any](s []T, fn func(T) K, c Comparator[K]) {
+	sortKeyed(s, fn, c, false)
+}
+
+// SortStableWithKey is like SortWithKey, but keeps elements with equal keys in
+// their original order
+func SortStableWithKey[T, K any](s []T, fn func(T) K, c Comparator[K]) {
+	sortKeyed(s, fn, c, true)
+}
+
+// SortedWithKey returns a new slice with the elements in the order of the keys
+// returned by the given selector function as given by the comparator. The
+// selector is called once per element.
+func SortedWithKey[T, K any](s []T, fn func(T) K, c Comparator[K]) []T {
+	ret := cloned(s)
+	SortWithKey(ret, fn, c)
+	return ret
+}
+
+// SortedStableWithKey is like SortedWithKey, but keeps elements with equal
+// keys in their original order
+func SortedStableWithKey[T, K any](s []T, fn func(T) K, c Comparator[K]) []T {
+	ret := cloned(s)
+	SortStableWithKey(ret, fn, c)
+	return ret
+}
+
+// sortByKey sorts s by the keys returned by fn, computing each key only once
+func sortByKey[T any, K cmp.Ordered](s []T, fn func(T) K, desc, stable bool) {
+	c := Comparator[K](cmp.Compare[K])
+	if desc {
+		c = c.Reversed()
+	}
+	sortKeyed(s, fn, c, stable)
+}
+
+// sortKeyed sorts s by the keys returned by fn, ordered by c, computing each
+// key only once
+func sortKeyed[T, K any](s []T, fn func(T) K, c Comparator[K], stable bool) {
+	type keyed struct {
+		key K
+		val T
+	}
+	ks := make([]keyed, len(s))
+	for i, e := range s {
+		ks[i] = keyed{fn(e), e}
+	}
+	compare := func(a, b keyed) int {
+		return c(a.key, b.key)
+	}
+	if stable {
+		slices.SortStableFunc(ks, compare)
+	} else {
+		slices.SortFunc(ks, compare)
+	}
+	for i, k := range ks {
+		s[i] = k.val
+	}
+}
@@ -0,0 +1,208 @@
+package fun
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type employee struct {
+	name string
+	dept string
+	age  int
+}
+
+func employeeNames(es []employee) []string {
+	return Map(es, func(e employee) string { return e.name })
+}
+
+func TestSortBy(t *testing.T) {
+	words := []string{"ccc", "a", "bb", "dd", "e"}
+	byLen := func(s string) int { return len(s) }
+	tests := []struct {
+		name string
+		sort func([]string) []string
+		want []string
+	}{
+		{"SortedStableBy",
+			func(s []string) []string { return SortedStableBy(s, byLen) },
+			[]string{"a", "e", "bb", "dd", "ccc"},
+		},
+		{"SortedStableByDescending",
+			func(s []string) []string { return SortedStableByDescending(s, byLen) },
+			[]string{"ccc", "bb", "dd", "a", "e"},
+		},
+		{"SortedBy",
+			func(s []string) []string { return SortedBy(s, strings.ToUpper) },
+			[]string{"a", "bb", "ccc", "dd", "e"},
+		},
+		{"SortedByDescending",
+			func(s []string) []string { return SortedByDescending(s, strings.ToUpper) },
+			[]string{"e", "dd", "ccc", "bb", "a"},
+		},
+		{"SortStableBy",
+			func(s []string) []string { s = Reversed(s); SortStableBy(s, byLen); return s },
+			[]string{"e", "a", "dd", "bb", "ccc"},
+		},
+		{"SortStableByDescending",
+			func(s []string) []string { s = Reversed(s); SortStableByDescending(s, byLen); return s },
+			[]string{"ccc", "dd", "bb", "e", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sort(words); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+	if !reflect.DeepEqual(words, []string{"ccc", "a", "bb", "dd", "e"}) {
+		t.Errorf("copying sorts modified their input: %v", words)
+	}
+
+	// unstable sorts only guarantee the order of the keys
+	s := Reversed(words)
+	SortBy(s, byLen)
+	if got := Map(s, byLen); !reflect.DeepEqual(got, []int{1, 1, 2, 2, 3}) {
+		t.Errorf("SortBy() key order = %v, want [1 1 2 2 3]", got)
+	}
+	SortByDescending(s, byLen)
+	if got := Map(s, byLen); !reflect.DeepEqual(got, []int{3, 2, 2, 1, 1}) {
+		t.Errorf("SortByDescending() key order = %v, want [3 2 2 1 1]", got)
+	}
+}
+
+func TestSortByComputesKeysOnce(t *testing.T) {
+	s := []int{5, 3, 8, 1, 9, 2, 7}
+	calls := 0
+	SortBy(s, func(i int) int { calls++; return i })
+	if calls != len(s) {
+		t.Errorf("SortBy() called selector %d times, want %d", calls, len(s))
+	}
+	if !reflect.DeepEqual(s, []int{1, 2, 3, 5, 7, 8, 9}) {
+		t.Errorf("SortBy() = %v", s)
+	}
+}
+
+func TestSortWithKeyComputesKeysOnce(t *testing.T) {
+	staff := []employee{
+		{"dan", "ops", 40},
+		{"amy", "dev", 30},
+		{"bob", "ops", 25},
+		{"cat", "dev", 30},
+		{"eve", "dev", 45},
+	}
+	calls := 0
+	key := func(e employee) Pair[string, int] {
+		calls++
+		return Pair[string, int]{e.dept, e.age}
+	}
+	byDeptAge := ThenByDescending(
+		ComparingBy(func(k Pair[string, int]) string { return k.Fst }),
+		func(k Pair[string, int]) int { return k.Snd },
+	)
+	got := SortedStableWithKey(staff, key, byDeptAge)
+	if calls != len(staff) {
+		t.Errorf("SortedStableWithKey() called selector %d times, want %d", calls, len(staff))
+	}
+	names := Map(got, func(e employee) string { return e.name })
+	if want := []string{"eve", "amy", "cat", "dan", "bob"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("SortedStableWithKey() = %v, want %v", names, want)
+	}
+
+	calls = 0
+	s := slices.Clone(staff)
+	SortWithKey(s, key, byDeptAge)
+	if calls != len(staff) {
+		t.Errorf("SortWithKey() called selector %d times, want %d", calls, len(staff))
+	}
+	if ages := Map(s, func(e employee) int { return e.age }); !reflect.DeepEqual(ages, []int{45, 30, 30, 40, 25}) {
+		t.Errorf("SortWithKey() ages = %v, want [45 30 30 40 25]", ages)
+	}
+}
+
+func TestComparator(t *testing.T) {
+	staff := []employee{
+		{"dan", "ops", 40},
+		{"amy", "dev", 30},
+		{"bob", "ops", 25},
+		{"cat", "dev", 30},
+		{"eve", "dev", 45},
+	}
+	byDept := ComparingBy(func(e employee) string { return e.dept })
+	byName := ComparingBy(func(e employee) string { return e.name })
+	tests := []struct {
+		name string
+		c    Comparator[employee]
+		want []string
+	}{
+		{"ThenBy",
+			ThenBy(byDept, func(e employee) int { return e.age }),
+			[]string{"amy", "cat", "eve", "bob", "dan"},
+		},
+		{"ThenByDescending",
+			ThenByDescending(byDept, func(e employee) int { return e.age }),
+			[]string{"eve", "amy", "cat", "dan", "bob"},
+		},
+		{"Then",
+			ThenByDescending(byDept, func(e employee) int { return e.age }).Then(byName.Reversed()),
+			[]string{"eve", "cat", "amy", "dan", "bob"},
+		},
+		{"Reversed",
+			byName.Reversed(),
+			[]string{"eve", "dan", "cat", "bob", "amy"},
+		},
+		{"ComparingByDescending",
+			ComparingByDescending(func(e employee) int { return e.age }).Then(byName),
+			[]string{"eve", "dan", "amy", "cat", "bob"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := employeeNames(SortedStableWith(staff, tt.c)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortedStableWith(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	stable := SortedStableWith(staff, byDept)
+	if got := employeeNames(stable); !reflect.DeepEqual(got, []string{"amy", "cat", "eve", "dan", "bob"}) {
+		t.Errorf("SortedStableWith() = %v", got)
+	}
+	if got := employeeNames(SortedWith(staff, byName)); !reflect.DeepEqual(got, []string{"amy", "bob", "cat", "dan", "eve"}) {
+		t.Errorf("SortedWith() = %v", got)
+	}
+	s := append([]employee(nil), staff...)
+	SortWith(s, byName)
+	if got := employeeNames(s); !reflect.DeepEqual(got, []string{"amy", "bob", "cat", "dan", "eve"}) {
+		t.Errorf("SortWith() = %v", got)
+	}
+	SortStableWith(s, byDept)
+	if got := employeeNames(s); !reflect.DeepEqual(got, []string{"amy", "cat", "eve", "bob", "dan"}) {
+		t.Errorf("SortStableWith() = %v", got)
+	}
+}
+
+func TestNullsFirstAndLast(t *testing.T) {
+	one, two, three := 1, 2, 3
+	s := []*int{&three, nil, &one, nil, &two}
+	deref := func(ps []*int) []int {
+		return Map(ps, func(p *int) int {
+			if p == nil {
+				return 0
+			}
+			return *p
+		})
+	}
+	byValue := ComparingBy(func(i int) int { return i })
+	if got := deref(SortedStableWith(s, NullsFirst(byValue))); !reflect.DeepEqual(got, []int{0, 0, 1, 2, 3}) {
+		t.Errorf("NullsFirst() = %v, want [0 0 1 2 3]", got)
+	}
+	if got := deref(SortedStableWith(s, NullsLast(byValue))); !reflect.DeepEqual(got, []int{1, 2, 3, 0, 0}) {
+		t.Errorf("NullsLast() = %v, want [1 2 3 0 0]", got)
+	}
+	if got := deref(SortedStableWith(s, NullsLast(byValue.Reversed()))); !reflect.DeepEqual(got, []int{3, 2, 1, 0, 0}) {
+		t.Errorf("NullsLast(Reversed()) = %v, want [3 2 1 0 0]", got)
+	}
+}
@@ -0,0 +1,309 @@
+package fun
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Interpolation selects how Percentile computes a value that falls between
+// two data points. The methods match those of numpy.percentile.
+type Interpolation int
+
+const (
+	// InterpolateLinear interpolates linearly between the two nearest data points
+	InterpolateLinear Interpolation = iota
+	// InterpolateLower takes the lower of the two nearest data points
+	InterpolateLower
+	// InterpolateHigher takes the higher of the two nearest data points
+	InterpolateHigher
+	// InterpolateNearest takes the nearest data point, rounding halves to even ranks
+	InterpolateNearest
+	// InterpolateMidpoint takes the average of the two nearest data points
+	InterpolateMidpoint
+)
+
+// Bin is a histogram bucket counting the values in [Lo, Hi). The last bin of
+// a histogram also counts values equal to its Hi.
+type Bin struct {
+	Lo, Hi float64
+	Count  int
+}
+
+// Stats summarises a set of numbers
+type Stats struct {
+	Count  int
+	Sum    float64
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	// StdDev is the population standard deviation
+	StdDev float64
+}
+
+// Median returns the median of the elements of the given slice, averaging
+// the two middle elements for an even number of elements, or an empty Option
+// for an empty slice
+func Median[T Number](s []T) Option[float64] {
+	return Percentile(s, 50, InterpolateLinear)
+}
+
+// MedianBy returns the median of the numbers returned by the given function
+// applied to each element of the given slice, like Median
+func MedianBy[T any, N Number](s []T, fn func(T) N) Option[float64] {
+	return Median(Map(s, fn))
+}
+
+// Percentile returns the p-th percentile of the elements of the given slice,
+// where p is between 0 and 100, using the given interpolation method.
+// It returns an empty Option for an empty slice or a p outside [0, 100].
+func Percentile[T Number](s []T, p float64, method Interpolation) Option[float64] {
+	if len(s) == 0 || !(p >= 0 && p <= 100) {
+		return None[float64]()
+	}
+	sorted := toSortedFloats(s)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := math.Floor(rank)
+	hi := math.Ceil(rank)
+	vLo, vHi := sorted[int(lo)], sorted[int(hi)]
+	switch method {
+	case InterpolateLower:
+		return Some(vLo)
+	case InterpolateHigher:
+		return Some(vHi)
+	case InterpolateNearest:
+		return Some(sorted[int(math.RoundToEven(rank))])
+	case InterpolateMidpoint:
+		return Some((vLo + vHi) / 2)
+	default:
+		return Some(vLo + (vHi-vLo)*(rank-lo))
+	}
+}
+
+// PercentileBy returns the p-th percentile of the numbers returned by the
+// given function applied to each element of the given slice, like Percentile
+func PercentileBy[T any, N Number](
+	s []T,
+	fn func(T) N,
+	p float64,
+	method Interpolation,
+) Option[float64] {
+	return Percentile(Map(s, fn), p, method)
+}
+
+// PopulationVariance returns the variance of the elements of the given slice,
+// treating them as the whole population, or an empty Option for an empty slice
+func PopulationVariance[T Number](s []T) Option[float64] {
+	if len(s) == 0 {
+		return None[float64]()
+	}
+	return Some(sumOfSquaredDeviations(s) / float64(len(s)))
+}
+
+// PopulationVarianceBy returns the variance of the numbers returned by the
+// given function applied to each element of the given slice, like
+// PopulationVariance
+func PopulationVarianceBy[T any, N Number](s []T, fn func(T) N) Option[float64] {
+	return PopulationVariance(Map(s, fn))
+}
+
+// SampleVariance returns the unbiased variance of the elements of the given
+// slice, treating them as a sample of a larger population, or an empty Option
+// for a slice with fewer than two elements
+func SampleVariance[T Number](s []T) Option[float64] {
+	if len(s) < 2 {
+		return None[float64]()
+	}
+	return Some(sumOfSquaredDeviations(s) / float64(len(s)-1))
+}
+
+// SampleVarianceBy returns the unbiased variance of the numbers returned by
+// the given function applied to each element of the given slice, like
+// SampleVariance
+func SampleVarianceBy[T any, N Number](s []T, fn func(T) N) Option[float64] {
+	return SampleVariance(Map(s, fn))
+}
+
+// PopulationStdDev returns the square root of PopulationVariance
+func PopulationStdDev[T Number](s []T) Option[float64] {
+	return OptionMap(PopulationVariance(s), math.Sqrt)
+}
+
+// PopulationStdDevBy returns the square root of PopulationVarianceBy
+func PopulationStdDevBy[T any, N Number](s []T, fn func(T) N) Option[float64] {
+	return OptionMap(PopulationVarianceBy(s, fn), math.Sqrt)
+}
+
+// SampleStdDev returns the square root of SampleVariance
+func SampleStdDev[T Number](s []T) Option[float64] {
+	return OptionMap(SampleVariance(s), math.Sqrt)
+}
+
+// SampleStdDevBy returns the square root of SampleVarianceBy
+func SampleStdDevBy[T any, N Number](s []T, fn func(T) N) Option[float64] {
+	return OptionMap(SampleVarianceBy(s, fn), math.Sqrt)
+}
+
+// Mode returns the most frequent elements of the given slice, in order of
+// their first occurrence. More than one element is returned when several are
+// equally frequent; an empty slice gives an empty result.
+func Mode[T comparable](s []T) []T {
+	counts := make(map[T]int)
+	best := 0
+	for _, e := range s {
+		counts[e]++
+		if counts[e] > best {
+			best = counts[e]
+		}
+	}
+	return Filter(Distinct(s), func(e T) bool { return counts[e] == best })
+}
+
+// ModeBy returns the most frequent of the keys returned by the given function
+// applied to each element of the given slice, like Mode
+func ModeBy[T any, K comparable](s []T, fn func(T) K) []K {
+	return Mode(Map(s, fn))
+}
+
+// Histogram counts the elements of the given slice in the given number of
+// equal-width bins spanning the range from the smallest to the largest
+// element. If all elements are equal, the bins span half a unit either side
+// of them. NaN and infinite elements are not counted and do not affect the
+// range. It returns an empty slice for an input without finite elements or a
+// non-positive number of bins.
+func Histogram[T Number](s []T, bins int) []Bin {
+	finite := make([]float64, 0, len(s))
+	for _, e := range s {
+		if v := float64(e); !math.IsNaN(v) && !math.IsInf(v, 0) {
+			finite = append(finite, v)
+		}
+	}
+	if len(finite) == 0 || bins <= 0 {
+		return make([]Bin, 0)
+	}
+	mm := MinMax(finite).MustGet()
+	lo, hi := mm.Fst, mm.Snd
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	// dividing each bound first keeps the width finite for extreme ranges
+	width := hi/float64(bins) - lo/float64(bins)
+	ret := make([]Bin, bins)
+	for i := range ret {
+		ret[i].Lo = lo + float64(i)*width
+		ret[i].Hi = lo + float64(i+1)*width
+	}
+	ret[bins-1].Hi = hi
+	for _, v := range finite {
+		i := bins - 1
+		if f := (v - lo) / width; f < float64(i) {
+			i = int(f)
+		}
+		ret[i].Count++
+	}
+	return ret
+}
+
+// HistogramBy counts the numbers returned by the given function applied to
+// each element of the given slice in equal-width bins, like Histogram
+func HistogramBy[T any, N Number](s []T, fn func(T) N, bins int) []Bin {
+	return Histogram(Map(s, fn), bins)
+}
+
+// HistogramEdges counts the elements of the given slice in the bins delimited
+// by the given ascending edges, so n edges make n-1 bins. Elements outside the
+// edges and NaN elements are not counted. It returns an empty slice if fewer
+// than two edges are given.
+func HistogramEdges[T Number](s []T, edges []float64) []Bin {
+	if len(edges) < 2 {
+		return make([]Bin, 0)
+	}
+	ret := make([]Bin, len(edges)-1)
+	for i := range ret {
+		ret[i].Lo, ret[i].Hi = edges[i], edges[i+1]
+	}
+	last := edges[len(edges)-1]
+	for _, e := range s {
+		v := float64(e)
+		if math.IsNaN(v) || v < edges[0] || v > last {
+			continue
+		}
+		if v == last {
+			ret[len(ret)-1].Count++
+			continue
+		}
+		// index of the first edge greater than v, less one
+		i := sort.Search(len(edges), func(j int) bool { return edges[j] > v }) - 1
+		ret[i].Count++
+	}
+	return ret
+}
+
+// HistogramEdgesBy counts the numbers returned by the given function applied
+// to each element of the given slice in the bins delimited by the given edges,
+// like HistogramEdges
+func HistogramEdgesBy[T any, N Number](s []T, fn func(T) N, edges []float64) []Bin {
+	return HistogramEdges(Map(s, fn), edges)
+}
+
+// Summarize returns the Stats of the elements of the given slice, or an empty
+// Option for an empty slice
+func Summarize[T Number](s []T) Option[Stats] {
+	if len(s) == 0 {
+		return None[Stats]()
+	}
+	sorted := toSortedFloats(s)
+	sum := Sum(sorted)
+	return Some(Stats{
+		Count:  len(sorted),
+		Sum:    sum,
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   sum / float64(len(sorted)),
+		Median: Median(sorted).MustGet(),
+		StdDev: PopulationStdDev(sorted).MustGet(),
+	})
+}
+
+// SummarizeBy returns the Stats of the numbers returned by the given function
+// applied to each element of the given slice, like Summarize
+func SummarizeBy[T any, N Number](s []T, fn func(T) N) Option[Stats] {
+	return Summarize(Map(s, fn))
+}
+
+// SummarizeGroups returns the Stats of each group of a map like the one
+// returned by GroupBy, using the numbers returned by the given function
+// applied to each element of the group. Empty groups are left out.
+func SummarizeGroups[M ~map[K][]V, K comparable, V any, N Number](
+	m M,
+	fn func(V) N,
+) map[K]Stats {
+	ret := make(map[K]Stats, len(m))
+	for k, vs := range m {
+		if st, ok := SummarizeBy(vs, fn).Get(); ok {
+			ret[k] = st
+		}
+	}
+	return ret
+}
+
+// toSortedFloats returns the elements of s converted to float64, in
+// ascending order
+func toSortedFloats[T Number](s []T) []float64 {
+	ret := Map(s, func(e T) float64 { return float64(e) })
+	slices.Sort(ret)
+	return ret
+}
+
+// sumOfSquaredDeviations returns the sum of the squared differences between
+// each element and the mean. The mean is computed first, in a separate pass,
+// which is more accurate than single-pass formulas for data with a large
+// offset.
+func sumOfSquaredDeviations[T Number](s []T) float64 {
+	floats := Map(s, func(e T) float64 { return float64(e) })
+	mean := compensatedSum(floats) / float64(len(floats))
+	return compensatedSum(Map(floats, func(x float64) float64 {
+		return (x - mean) * (x - mean)
+	}))
+}
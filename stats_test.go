@@ -0,0 +1,235 @@
+package fun
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []int
+		want Option[float64]
+	}{
+		{"odd count", []int{5, 1, 3}, Some(3.0)},
+		{"even count", []int{4, 1, 3, 2}, Some(2.5)},
+		{"single", []int{7}, Some(7.0)},
+		{"empty", []int{}, None[float64]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Median(tt.s); got != tt.want {
+				t.Errorf("Median() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	type rec struct{ ms int }
+	got := MedianBy([]rec{{10}, {30}, {20}}, func(r rec) int { return r.ms })
+	if got != Some(20.0) {
+		t.Errorf("MedianBy() = %v, want Some(20)", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	// values match numpy.percentile([1, 2, 3, 4], p, method=...)
+	s := []int{4, 1, 3, 2}
+	tests := []struct {
+		name   string
+		p      float64
+		method Interpolation
+		want   Option[float64]
+	}{
+		{"linear 40", 40, InterpolateLinear, Some(2.2)},
+		{"lower 40", 40, InterpolateLower, Some(2.0)},
+		{"higher 40", 40, InterpolateHigher, Some(3.0)},
+		{"nearest 40", 40, InterpolateNearest, Some(2.0)},
+		{"nearest 50 rounds half to even", 50, InterpolateNearest, Some(3.0)},
+		{"midpoint 40", 40, InterpolateMidpoint, Some(2.5)},
+		{"0th", 0, InterpolateLinear, Some(1.0)},
+		{"100th", 100, InterpolateLinear, Some(4.0)},
+		{"p too large", 101, InterpolateLinear, None[float64]()},
+		{"p negative", -1, InterpolateLinear, None[float64]()},
+		{"p NaN", math.NaN(), InterpolateLinear, None[float64]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Percentile(s, tt.p, tt.method)
+			gv, gok := got.Get()
+			wv, wok := tt.want.Get()
+			if gok != wok || !approxEqual(gv, wv) {
+				t.Errorf("Percentile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := Percentile([]float64{}, 50, InterpolateLinear); got.IsSome() {
+		t.Errorf("Percentile(empty) = %v, want None", got)
+	}
+	got := PercentileBy([]string{"a", "bb", "ccc"}, func(s string) int { return len(s) }, 50, InterpolateLower)
+	if got != Some(2.0) {
+		t.Errorf("PercentileBy() = %v, want Some(2)", got)
+	}
+}
+
+func TestVariance(t *testing.T) {
+	s := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	if got := PopulationVariance(s).MustGet(); !approxEqual(got, 4) {
+		t.Errorf("PopulationVariance() = %v, want 4", got)
+	}
+	if got := PopulationStdDev(s).MustGet(); !approxEqual(got, 2) {
+		t.Errorf("PopulationStdDev() = %v, want 2", got)
+	}
+	if got := SampleVariance(s).MustGet(); !approxEqual(got, 32.0/7) {
+		t.Errorf("SampleVariance() = %v, want %v", got, 32.0/7)
+	}
+	if got := SampleStdDev(s).MustGet(); !approxEqual(got, math.Sqrt(32.0/7)) {
+		t.Errorf("SampleStdDev() = %v, want %v", got, math.Sqrt(32.0/7))
+	}
+	type reading struct{ v float64 }
+	readings := Map(s, func(f float64) reading { return reading{f} })
+	value := func(r reading) float64 { return r.v }
+	if got := PopulationVarianceBy(readings, value).MustGet(); !approxEqual(got, 4) {
+		t.Errorf("PopulationVarianceBy() = %v, want 4", got)
+	}
+	if got := PopulationStdDevBy(readings, value).MustGet(); !approxEqual(got, 2) {
+		t.Errorf("PopulationStdDevBy() = %v, want 2", got)
+	}
+	if got := SampleVarianceBy(readings, value).MustGet(); !approxEqual(got, 32.0/7) {
+		t.Errorf("SampleVarianceBy() = %v, want %v", got, 32.0/7)
+	}
+	if got := SampleStdDevBy(readings, value).MustGet(); !approxEqual(got, math.Sqrt(32.0/7)) {
+		t.Errorf("SampleStdDevBy() = %v, want %v", got, math.Sqrt(32.0/7))
+	}
+	if PopulationVariance([]int{}).IsSome() || SampleVariance([]int{1}).IsSome() {
+		t.Errorf("variance of too few elements is not None")
+	}
+	// a large offset must not destroy precision
+	shifted := Map(s, func(f float64) float64 { return f + 1e9 })
+	if got := PopulationVariance(shifted).MustGet(); !approxEqual(got, 4) {
+		t.Errorf("PopulationVariance(shifted) = %v, want 4", got)
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []string
+		want []string
+	}{
+		{"single mode", []string{"a", "b", "b", "c"}, []string{"b"}},
+		{"multiple modes", []string{"c", "a", "c", "a", "b"}, []string{"c", "a"}},
+		{"empty", []string{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mode(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	got := ModeBy([]string{"a", "bb", "cc", "d", "ee"}, func(s string) int { return len(s) })
+	if want := []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ModeBy() = %v, want %v", got, want)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	got := Histogram([]int{1, 2, 2, 3, 4, 5}, 4)
+	want := []Bin{{1, 2, 1}, {2, 3, 2}, {3, 4, 1}, {4, 5, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Histogram() = %v, want %v", got, want)
+	}
+	got = Histogram([]float64{3, 3}, 2)
+	want = []Bin{{2.5, 3, 0}, {3, 3.5, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Histogram(equal values) = %v, want %v", got, want)
+	}
+	if got := Histogram([]int{}, 3); !reflect.DeepEqual(got, []Bin{}) {
+		t.Errorf("Histogram(empty) = %v, want []", got)
+	}
+	if got := Histogram([]int{1}, 0); !reflect.DeepEqual(got, []Bin{}) {
+		t.Errorf("Histogram(0 bins) = %v, want []", got)
+	}
+
+	// non-finite values are skipped and do not affect the range
+	inf := math.Inf(1)
+	want = []Bin{{1, 2, 1}, {2, 3, 1}}
+	for _, s := range [][]float64{
+		{1, math.NaN(), 3},
+		{1, inf, 3, -inf},
+		{math.NaN(), 1, 3, inf},
+	} {
+		if got := Histogram(s, 2); !reflect.DeepEqual(got, want) {
+			t.Errorf("Histogram(%v) = %v, want %v", s, got, want)
+		}
+	}
+	if got := Histogram([]float64{1, inf}, 2); !reflect.DeepEqual(got, []Bin{{0.5, 1, 0}, {1, 1.5, 1}}) {
+		t.Errorf("Histogram(1, +Inf) = %v, want one finite value", got)
+	}
+	if got := Histogram([]float64{math.NaN(), inf}, 2); !reflect.DeepEqual(got, []Bin{}) {
+		t.Errorf("Histogram(no finite values) = %v, want []", got)
+	}
+	got = Histogram([]float64{-math.MaxFloat64, 0, math.MaxFloat64}, 2)
+	if Sum(Map(got, func(b Bin) int { return b.Count })) != 3 {
+		t.Errorf("Histogram(extreme range) = %v, want 3 values counted", got)
+	}
+}
+
+func TestHistogramEdges(t *testing.T) {
+	got := HistogramEdges([]float64{-1, 0, 0.5, 1, 1.5, 3, 10, 11}, []float64{0, 1, 3, 10})
+	want := []Bin{{0, 1, 2}, {1, 3, 2}, {3, 10, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HistogramEdges() = %v, want %v", got, want)
+	}
+	type rec struct{ ms int }
+	recs := []rec{{5}, {15}, {25}, {30}}
+	got = HistogramEdgesBy(recs, func(r rec) int { return r.ms }, []float64{0, 10, 30})
+	if want := []Bin{{0, 10, 1}, {10, 30, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HistogramEdgesBy() = %v, want %v", got, want)
+	}
+	got = HistogramBy(recs, func(r rec) int { return r.ms }, 1)
+	if want := []Bin{{5, 30, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HistogramBy() = %v, want %v", got, want)
+	}
+	if got := HistogramEdges([]int{1}, []float64{0}); !reflect.DeepEqual(got, []Bin{}) {
+		t.Errorf("HistogramEdges(one edge) = %v, want []", got)
+	}
+	got = HistogramEdges([]float64{math.NaN(), 0.5, math.Inf(1), 1.5, math.Inf(-1)}, []float64{0, 1, 2})
+	if want := []Bin{{0, 1, 1}, {1, 2, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HistogramEdges(non-finite values) = %v, want %v", got, want)
+	}
+}
+
+func TestSummarizeGroups(t *testing.T) {
+	type sale struct {
+		region string
+		amount int
+	}
+	sales := []sale{{"north", 10}, {"south", 5}, {"north", 30}, {"north", 20}}
+	groups := GroupBy(sales, func(s sale) (string, sale) { return s.region, s })
+	got := SummarizeGroups(groups, func(s sale) int { return s.amount })
+	north := Stats{
+		Count: 3, Sum: 60, Min: 10, Max: 30, Mean: 20, Median: 20,
+		StdDev: math.Sqrt(200.0 / 3),
+	}
+	south := Stats{Count: 1, Sum: 5, Min: 5, Max: 5, Mean: 5, Median: 5}
+	if got["north"].Count != north.Count || !approxEqual(got["north"].StdDev, north.StdDev) {
+		t.Errorf("SummarizeGroups()[north] = %+v, want %+v", got["north"], north)
+	}
+	gotNorth := got["north"]
+	gotNorth.StdDev = north.StdDev
+	if gotNorth != north || got["south"] != south || len(got) != 2 {
+		t.Errorf("SummarizeGroups() = %+v, want north %+v south %+v", got, north, south)
+	}
+	if Summarize([]int{}).IsSome() {
+		t.Errorf("Summarize(empty) is not None")
+	}
+	inf := Summarize([]float64{1, math.Inf(1)}).MustGet()
+	if !math.IsInf(inf.Sum, 1) || !math.IsInf(inf.Mean, 1) || !math.IsInf(inf.Max, 1) {
+		t.Errorf("Summarize(1, +Inf) = %+v, want an infinite Sum, Mean and Max", inf)
+	}
+}
@@ -0,0 +1,7 @@
+package fun
+
+// The tuple types of arity 3 and up, and the Zip, ZipWith and Unzip functions
+// for each arity, are generated so that they stay consistent with each other
+// and with Pair, Zip and Unzip.
+
+//go:generate go run gen_tuple.go
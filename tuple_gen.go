@@ -0,0 +1,177 @@
+// Code generated by gen_tuple.go; DO NOT EDIT.
+
+package fun
+
+import "fmt"
+
+// ZipWith returns a slice of the results of applying the given function
+// to the elements of both slices with the same index, without
+// allocating a pair for each of them. The returned slice has the length of
+// the shortest input slice.
+func ZipWith[T1, T2, R any](s1 []T1, s2 []T2, fn func(T1, T2) R) []R {
+	n := min(len(s1), len(s2))
+	ret := make([]R, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, fn(s1[i], s2[i]))
+	}
+	return ret
+}
+
+// Triple represents a generic triple of three values
+type Triple[T1, T2, T3 any] struct {
+	Fst T1
+	Snd T2
+	Thd T3
+}
+
+func (t Triple[T1, T2, T3]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", t.Fst, t.Snd, t.Thd)
+}
+
+// Zip3 returns a slice of triples from the elements of
+// all three slices with the same index. The returned slice has the length of the
+// shortest input slice.
+func Zip3[T1, T2, T3 any](s1 []T1, s2 []T2, s3 []T3) []*Triple[T1, T2, T3] {
+	n := min(len(s1), len(s2), len(s3))
+	ret := make([]*Triple[T1, T2, T3], 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, &Triple[T1, T2, T3]{s1[i], s2[i], s3[i]})
+	}
+	return ret
+}
+
+// Unzip3 returns three slices, where the n-th slice is built from
+// the n-th values of each triple from the input slice
+func Unzip3[T1, T2, T3 any](ts []*Triple[T1, T2, T3]) ([]T1, []T2, []T3) {
+	s1 := make([]T1, 0, len(ts))
+	s2 := make([]T2, 0, len(ts))
+	s3 := make([]T3, 0, len(ts))
+	for _, t := range ts {
+		s1 = append(s1, t.Fst)
+		s2 = append(s2, t.Snd)
+		s3 = append(s3, t.Thd)
+	}
+	return s1, s2, s3
+}
+
+// ZipWith3 returns a slice of the results of applying the given function
+// to the elements of all three slices with the same index, without
+// allocating a triple for each of them. The returned slice has the length of
+// the shortest input slice.
+func ZipWith3[T1, T2, T3, R any](s1 []T1, s2 []T2, s3 []T3, fn func(T1, T2, T3) R) []R {
+	n := min(len(s1), len(s2), len(s3))
+	ret := make([]R, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, fn(s1[i], s2[i], s3[i]))
+	}
+	return ret
+}
+
+// Quad represents a generic quadruple of four values
+type Quad[T1, T2, T3, T4 any] struct {
+	Fst T1
+	Snd T2
+	Thd T3
+	Fth T4
+}
+
+func (t Quad[T1, T2, T3, T4]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v)", t.Fst, t.Snd, t.Thd, t.Fth)
+}
+
+// Zip4 returns a slice of quadruples from the elements of
+// all four slices with the same index. The returned slice has the length of the
+// shortest input slice.
+func Zip4[T1, T2, T3, T4 any](s1 []T1, s2 []T2, s3 []T3, s4 []T4) []*Quad[T1, T2, T3, T4] {
+	n := min(len(s1), len(s2), len(s3), len(s4))
+	ret := make([]*Quad[T1, T2, T3, T4], 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, &Quad[T1, T2, T3, T4]{s1[i], s2[i], s3[i], s4[i]})
+	}
+	return ret
+}
+
+// Unzip4 returns four slices, where the n-th slice is built from
+// the n-th values of each quadruple from the input slice
+func Unzip4[T1, T2, T3, T4 any](ts []*Quad[T1, T2, T3, T4]) ([]T1, []T2, []T3, []T4) {
+	s1 := make([]T1, 0, len(ts))
+	s2 := make([]T2, 0, len(ts))
+	s3 := make([]T3, 0, len(ts))
+	s4 := make([]T4, 0, len(ts))
+	for _, t := range ts {
+		s1 = append(s1, t.Fst)
+		s2 = append(s2, t.Snd)
+		s3 = append(s3, t.Thd)
+		s4 = append(s4, t.Fth)
+	}
+	return s1, s2, s3, s4
+}
+
+// ZipWith4 returns a slice of the results of applying the given function
+// to the elements of all four slices with the same index, without
+// allocating a quadruple for each of them. The returned slice has the length of
+// the shortest input slice.
+func ZipWith4[T1, T2, T3, T4, R any](s1 []T1, s2 []T2, s3 []T3, s4 []T4, fn func(T1, T2, T3, T4) R) []R {
+	n := min(len(s1), len(s2), len(s3), len(s4))
+	ret := make([]R, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, fn(s1[i], s2[i], s3[i], s4[i]))
+	}
+	return ret
+}
+
+// Quint represents a generic quintuple of five values
+type Quint[T1, T2, T3, T4, T5 any] struct {
+	Fst T1
+	Snd T2
+	Thd T3
+	Fth T4
+	Fif T5
+}
+
+func (t Quint[T1, T2, T3, T4, T5]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v, %v)", t.Fst, t.Snd, t.Thd, t.Fth, t.Fif)
+}
+
+// Zip5 returns a slice of quintuples from the elements of
+// all five slices with the same index. The returned slice has the length of the
+// shortest input slice.
+func Zip5[T1, T2, T3, T4, T5 any](s1 []T1, s2 []T2, s3 []T3, s4 []T4, s5 []T5) []*Quint[T1, T2, T3, T4, T5] {
+	n := min(len(s1), len(s2), len(s3), len(s4), len(s5))
+	ret := make([]*Quint[T1, T2, T3, T4, T5], 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, &Quint[T1, T2, T3, T4, T5]{s1[i], s2[i], s3[i], s4[i], s5[i]})
+	}
+	return ret
+}
+
+// Unzip5 returns five slices, where the n-th slice is built from
+// the n-th values of each quintuple from the input slice
+func Unzip5[T1, T2, T3, T4, T5 any](ts []*Quint[T1, T2, T3, T4, T5]) ([]T1, []T2, []T3, []T4, []T5) {
+	s1 := make([]T1, 0, len(ts))
+	s2 := make([]T2, 0, len(ts))
+	s3 := make([]T3, 0, len(ts))
+	s4 := make([]T4, 0, len(ts))
+	s5 := make([]T5, 0, len(ts))
+	for _, t := range ts {
+		s1 = append(s1, t.Fst)
+		s2 = append(s2, t.Snd)
+		s3 = append(s3, t.Thd)
+		s4 = append(s4, t.Fth)
+		s5 = append(s5, t.Fif)
+	}
+	return s1, s2, s3, s4, s5
+}
+
+// ZipWith5 returns a slice of the results of applying the given function
+// to the elements of all five slices with the same index, without
+// allocating a quintuple for each of them. The returned slice has the length of
+// the shortest input slice.
+func ZipWith5[T1, T2, T3, T4, T5, R any](s1 []T1, s2 []T2, s3 []T3, s4 []T4, s5 []T5, fn func(T1, T2, T3, T4, T5) R) []R {
+	n := min(len(s1), len(s2), len(s3), len(s4), len(s5))
+	ret := make([]R, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, fn(s1[i], s2[i], s3[i], s4[i], s5[i]))
+	}
+	return ret
+}
@@ -0,0 +1,76 @@
+package fun
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestZip3(t *testing.T) {
+	got := Zip3([]int{1, 2, 3}, []string{"a", "b"}, []bool{true, false, true})
+	want := []*Triple[int, string, bool]{{1, "a", true}, {2, "b", false}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Zip3() = %v, want %v", got, want)
+	}
+	if s := got[0].String(); s != "(1, a, true)" {
+		t.Errorf("Triple.String() = %v, want (1, a, true)", s)
+	}
+	ints, strs, bools := Unzip3(got)
+	if !reflect.DeepEqual(ints, []int{1, 2}) ||
+		!reflect.DeepEqual(strs, []string{"a", "b"}) ||
+		!reflect.DeepEqual(bools, []bool{true, false}) {
+		t.Errorf("Unzip3() = %v, %v, %v", ints, strs, bools)
+	}
+}
+
+func TestZip4(t *testing.T) {
+	got := Zip4([]int{1}, []int{2}, []int{3}, []int{4, 5})
+	if len(got) != 1 || got[0].String() != "(1, 2, 3, 4)" {
+		t.Errorf("Zip4() = %v, want [(1, 2, 3, 4)]", got)
+	}
+	s1, s2, s3, s4 := Unzip4(got)
+	if !reflect.DeepEqual([][]int{s1, s2, s3, s4}, [][]int{{1}, {2}, {3}, {4}}) {
+		t.Errorf("Unzip4() = %v, %v, %v, %v", s1, s2, s3, s4)
+	}
+}
+
+func TestZip5(t *testing.T) {
+	got := Zip5([]int{1}, []int{2}, []int{3}, []int{4}, []string{"e"})
+	if len(got) != 1 || got[0].String() != "(1, 2, 3, 4, e)" {
+		t.Errorf("Zip5() = %v, want [(1, 2, 3, 4, e)]", got)
+	}
+	_, _, _, _, s5 := Unzip5(got)
+	if !reflect.DeepEqual(s5, []string{"e"}) {
+		t.Errorf("Unzip5() fifth slice = %v, want [e]", s5)
+	}
+	if got := Zip5([]int{}, []int{1}, []int{1}, []int{1}, []int{1}); len(got) != 0 {
+		t.Errorf("Zip5() with an empty slice = %v, want []", got)
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	got := ZipWith([]string{"a", "b", "c"}, []int{1, 2}, func(s string, i int) string {
+		return s + strconv.Itoa(i)
+	})
+	if want := []string{"a1", "b2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ZipWith() = %v, want %v", got, want)
+	}
+	sums := ZipWith3([]int{1, 2}, []int{10, 20}, []int{100, 200}, func(a, b, c int) int {
+		return a + b + c
+	})
+	if want := []int{111, 222}; !reflect.DeepEqual(sums, want) {
+		t.Errorf("ZipWith3() = %v, want %v", sums, want)
+	}
+	sums = ZipWith4([]int{1}, []int{2}, []int{3}, []int{4}, func(a, b, c, d int) int {
+		return a + b + c + d
+	})
+	if want := []int{10}; !reflect.DeepEqual(sums, want) {
+		t.Errorf("ZipWith4() = %v, want %v", sums, want)
+	}
+	sums = ZipWith5([]int{1}, []int{2}, []int{3}, []int{4}, []int{5}, func(a, b, c, d, e int) int {
+		return a + b + c + d + e
+	})
+	if want := []int{15}; !reflect.DeepEqual(sums, want) {
+		t.Errorf("ZipWith5() = %v, want %v", sums, want)
+	}
+}
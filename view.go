@@ -0,0 +1,40 @@
+package fun
+
+// WindowedView returns the same sliding windows as Windowed, but each window
+// is a view into the given slice rather than a copy, so only the outer slice
+// is allocated. Changes to the elements of the given slice are visible through
+// the windows, and vice versa. Each window's capacity is limited to its length,
+// so appending to a window copies it instead of overwriting the elements that
+// follow it. Like Windowed, it panics if size or step is not positive.
+func WindowedView[T any](s []T, size, step int) [][]T {
+	mustCheck(checkWindow(size, step))
+	n := windowCount(len(s), step)
+	ret := make([][]T, 0, n)
+	for i := range n {
+		start := i * step
+		end := start + min(size, len(s)-start)
+		ret = append(ret, s[start:end:end])
+	}
+	return ret
+}
+
+// ChunkedView returns the same chunks as Chunked, but each chunk is a view into
+// the given slice rather than a copy, so only the outer slice is allocated.
+// Changes to the elements of the given slice are visible through the chunks,
+// and vice versa. Each chunk's capacity is limited to its length, so appending
+// to a chunk copies it instead of overwriting the next chunk. Like Chunked, it
+// panics if chunkSize is not positive.
+func ChunkedView[T any](s []T, chunkSize int) [][]T {
+	mustCheck(checkSize(chunkSize))
+	return WindowedView(s, chunkSize, chunkSize)
+}
+
+// windowCount returns the number of windows starting at multiples of the given
+// positive step within a slice of length n, without overflowing for a large
+// step
+func windowCount(n, step int) int {
+	if n == 0 {
+		return 0
+	}
+	return (n-1)/step + 1
+}
@@ -0,0 +1,105 @@
+package fun
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestWindowedView(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		name       string
+		size, step int
+	}{
+		{"overlapping", 5, 1},
+		{"step larger than one", 5, 3},
+		{"step equal to size", 3, 3},
+		{"step larger than size", 2, 4},
+		{"size larger than slice", 20, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WindowedView(s, tt.size, tt.step)
+			if want := Windowed(s, tt.size, tt.step); !reflect.DeepEqual(got, want) {
+				t.Errorf("WindowedView() = %v, want %v", got, want)
+			}
+		})
+	}
+	if got := WindowedView([]int{}, 2, 1); !reflect.DeepEqual(got, [][]int{}) {
+		t.Errorf("WindowedView(empty) = %v, want []", got)
+	}
+	// sizes and steps near math.MaxInt must not overflow
+	if got := WindowedView([]int{1, 2}, 2, math.MaxInt); !reflect.DeepEqual(got, [][]int{{1, 2}}) {
+		t.Errorf("WindowedView(step MaxInt) = %v, want [[1 2]]", got)
+	}
+	if got := WindowedView([]int{1, 2, 3}, math.MaxInt, 1); !reflect.DeepEqual(got, [][]int{{1, 2, 3}, {2, 3}, {3}}) {
+		t.Errorf("WindowedView(size MaxInt) = %v, want [[1 2 3] [2 3] [3]]", got)
+	}
+}
+
+func TestChunkedView(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7}
+	for _, size := range []int{1, 2, 3, 7, 10} {
+		if got, want := ChunkedView(s, size), Chunked(s, size); !reflect.DeepEqual(got, want) {
+			t.Errorf("ChunkedView(%d) = %v, want %v", size, got, want)
+		}
+	}
+	if got := ChunkedView([]int{}, 2); !reflect.DeepEqual(got, [][]int{}) {
+		t.Errorf("ChunkedView(empty) = %v, want []", got)
+	}
+	if got := ChunkedView([]int{1, 2}, math.MaxInt); !reflect.DeepEqual(got, [][]int{{1, 2}}) {
+		t.Errorf("ChunkedView(MaxInt) = %v, want [[1 2]]", got)
+	}
+}
+
+func TestViewAliasing(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	chunks := ChunkedView(s, 2)
+	// the views share the backing array of s
+	s[0] = 10
+	chunks[1][0] = 30
+	if chunks[0][0] != 10 || s[2] != 30 {
+		t.Errorf("ChunkedView() does not alias: s = %v, chunks = %v", s, chunks)
+	}
+	// but appending to a view must not overwrite the next one
+	_ = append(chunks[0], 99)
+	if s[2] != 30 || chunks[1][0] != 30 {
+		t.Errorf("append to chunk overwrote the next chunk: s = %v", s)
+	}
+	windows := WindowedView(s, 2, 2)
+	_ = append(windows[0], 99)
+	if s[2] != 30 {
+		t.Errorf("append to window overwrote the slice: s = %v", s)
+	}
+}
+
+var viewBenchInput = make([]int, 100_000)
+
+func BenchmarkWindowed(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Windowed(viewBenchInput, 100, 1)
+	}
+}
+
+func BenchmarkWindowedView(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		WindowedView(viewBenchInput, 100, 1)
+	}
+}
+
+func BenchmarkChunked(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		Chunked(viewBenchInput, 100)
+	}
+}
+
+func BenchmarkChunkedView(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		ChunkedView(viewBenchInput, 100)
+	}
+}
@@ -0,0 +1,80 @@
+package fun
+
+import "slices"
+
+// WindowOption configures WindowedOpts and WindowedTransform
+type WindowOption[T any] func(*windowConfig[T])
+
+type windowConfig[T any] struct {
+	partial bool
+	padded  bool
+	pad     T
+}
+
+// PartialWindows sets whether the trailing windows that are shorter than the
+// window size are kept. They are kept by default, as in Windowed; Kotlin's
+// windowed drops them by default.
+func PartialWindows[T any](partial bool) WindowOption[T] {
+	return func(c *windowConfig[T]) {
+		c.partial = partial
+	}
+}
+
+// PadWindows fills the trailing partial windows up to the window size with the
+// given value. It has no effect if partial windows are dropped.
+func PadWindows[T any](pad T) WindowOption[T] {
+	return func(c *windowConfig[T]) {
+		c.padded = true
+		c.pad = pad
+	}
+}
+
+// WindowedOpts is like Windowed, but configured with the given options.
+// Windows start at every step-th element of the given slice, so the last
+// windows may be shorter than size; PartialWindows and PadWindows select what
+// happens to them. Each window is a new slice. It panics if size or step is not
+// positive, like Windowed.
+func WindowedOpts[T any](s []T, size, step int, opts ...WindowOption[T]) [][]T {
+	return WindowedTransform(s, size, step, slices.Clone[[]T], opts...)
+}
+
+// WindowedTransform returns the results of applying the given function to each
+// window selected as by WindowedOpts, without copying the windows. The window
+// passed to the function is a view into the given slice, or, for a padded
+// window, a buffer reused for the next padded window, so the function must
+// copy it to retain it. It panics if size or step is not positive, like
+// Windowed.
+func WindowedTransform[T, R any](
+	s []T,
+	size, step int,
+	fn func([]T) R,
+	opts ...WindowOption[T],
+) []R {
+	mustCheck(checkWindow(size, step))
+	cfg := windowConfig[T]{partial: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	n := windowCount(len(s), step)
+	ret := make([]R, 0, n)
+	var buf []T
+	for i := range n {
+		start := i * step
+		end := start + min(size, len(s)-start)
+		w := s[start:end:end]
+		if len(w) < size {
+			if !cfg.partial {
+				break
+			}
+			if cfg.padded {
+				buf = append(buf[:0], w...)
+				for len(buf) < size {
+					buf = append(buf, cfg.pad)
+				}
+				w = buf
+			}
+		}
+		ret = append(ret, fn(w))
+	}
+	return ret
+}
@@ -0,0 +1,129 @@
+package fun
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWindowedOpts(t *testing.T) {
+	// cases from the documentation of Kotlin's windowed
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		name       string
+		size, step int
+		opts       []WindowOption[int]
+		want       [][]int
+	}{
+		{
+			"partial by default", 5, 3, nil,
+			[][]int{{1, 2, 3, 4, 5}, {4, 5, 6, 7, 8}, {7, 8, 9, 10}, {10}},
+		},
+		{
+			"without partial", 5, 3, []WindowOption[int]{PartialWindows[int](false)},
+			[][]int{{1, 2, 3, 4, 5}, {4, 5, 6, 7, 8}},
+		},
+		{
+			"padded", 5, 3, []WindowOption[int]{PadWindows(0)},
+			[][]int{{1, 2, 3, 4, 5}, {4, 5, 6, 7, 8}, {7, 8, 9, 10, 0}, {10, 0, 0, 0, 0}},
+		},
+		{
+			"padding ignored without partial", 5, 3,
+			[]WindowOption[int]{PadWindows(0), PartialWindows[int](false)},
+			[][]int{{1, 2, 3, 4, 5}, {4, 5, 6, 7, 8}},
+		},
+		{
+			"step larger than size", 2, 4, []WindowOption[int]{PartialWindows[int](false)},
+			[][]int{{1, 2}, {5, 6}, {9, 10}},
+		},
+		{
+			"size larger than slice", 11, 1, []WindowOption[int]{PartialWindows[int](false)},
+			[][]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WindowedOpts(s, tt.size, tt.step, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WindowedOpts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowedOptsAllSizes(t *testing.T) {
+	// every combination of input length, size and step is checked against
+	// Windowed, which keeps partial windows
+	for n := 0; n <= 7; n++ {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i + 1
+		}
+		for size := 1; size <= 8; size++ {
+			for step := 1; step <= 8; step++ {
+				all := Windowed(s, size, step)
+				full := Filter(all, func(w []int) bool { return len(w) == size })
+				padded := Map(all, func(w []int) []int {
+					return append(slices.Clone(w), slices.Repeat([]int{-1}, size-len(w))...)
+				})
+				if got := WindowedOpts(s, size, step); !reflect.DeepEqual(got, all) {
+					t.Errorf("WindowedOpts(%v, %d, %d) = %v, want %v", s, size, step, got, all)
+				}
+				got := WindowedOpts(s, size, step, PartialWindows[int](false))
+				if !reflect.DeepEqual(got, full) {
+					t.Errorf("WindowedOpts(%v, %d, %d, no partial) = %v, want %v", s, size, step, got, full)
+				}
+				if got := WindowedOpts(s, size, step, PadWindows(-1)); !reflect.DeepEqual(got, padded) {
+					t.Errorf("WindowedOpts(%v, %d, %d, padded) = %v, want %v", s, size, step, got, padded)
+				}
+			}
+		}
+	}
+}
+
+func TestWindowedOptsLargeArgs(t *testing.T) {
+	// sizes and steps near math.MaxInt must not overflow
+	s := []int{1, 2, 3}
+	for _, tt := range []struct {
+		size, step int
+	}{
+		{2, math.MaxInt},
+		{math.MaxInt, 1},
+		{math.MaxInt, math.MaxInt},
+	} {
+		want := Windowed(s, tt.size, tt.step)
+		if got := WindowedOpts(s, tt.size, tt.step); !reflect.DeepEqual(got, want) {
+			t.Errorf("WindowedOpts(%d, %d) = %v, want %v", tt.size, tt.step, got, want)
+		}
+		want = Filter(want, func(w []int) bool { return len(w) == tt.size })
+		got := WindowedOpts(s, tt.size, tt.step, PartialWindows[int](false))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("WindowedOpts(%d, %d, no partial) = %v, want %v", tt.size, tt.step, got, want)
+		}
+	}
+}
+
+func TestWindowedTransform(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	got := WindowedTransform(s, 2, 2, func(w []int) int { return Sum(w) })
+	if want := []int{3, 7, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowedTransform() = %v, want %v", got, want)
+	}
+	got = WindowedTransform(s, 3, 1, func(w []int) int { return Sum(w) }, PartialWindows[int](false))
+	if want := []int{6, 9, 12}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowedTransform(no partial) = %v, want %v", got, want)
+	}
+	got = WindowedTransform(s, 3, 2, func(w []int) int { return w[2] }, PadWindows(100))
+	if want := []int{3, 5, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WindowedTransform(padded) = %v, want %v", got, want)
+	}
+	// unpadded windows are views into the input
+	views := WindowedTransform(s, 2, 2, func(w []int) []int { return w })
+	s[0] = 10
+	if views[0][0] != 10 || cap(views[0]) != 2 {
+		t.Errorf("WindowedTransform() windows are not capped views: %v", views)
+	}
+	if err := panicErr(func() { WindowedOpts(s, 0, 1) }); err == nil {
+		t.Errorf("WindowedOpts(size 0) did not panic")
+	}
+}